@@ -15,12 +15,19 @@
 package certificates
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/hex"
 	"time"
+
+	"github.com/greymatter-io/acert/keys"
 )
 
+// OIDOCSPNoCheck defines the object identifier of the OCSP no check extension (see RFC 6960 section 4.2.2.2.1).
+var OIDOCSPNoCheck = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}
+
 // CommonName returns the common name of a certificate.
 func CommonName(certificate *x509.Certificate) string {
 	return certificate.Subject.CommonName
@@ -33,6 +40,48 @@ func Expiration(certificate *x509.Certificate) time.Time {
 
 // Fingerprint returns the SHA256 hash of a certificate truncated to twelve characters.
 func Fingerprint(certificate *x509.Certificate) string {
+	return FullFingerprint(certificate)[0:12]
+}
+
+// FullFingerprint returns the SHA256 hash of a certificate.
+func FullFingerprint(certificate *x509.Certificate) string {
 	bytes := sha256.Sum256(certificate.Raw)
-	return hex.EncodeToString(bytes[:])[0:12]
+	return hex.EncodeToString(bytes[:])
+}
+
+// RequestFingerprint returns the SHA256 hash of a certificate request truncated to twelve characters.
+func RequestFingerprint(request *x509.CertificateRequest) string {
+	return FullRequestFingerprint(request)[0:12]
+}
+
+// FullRequestFingerprint returns the SHA256 hash of a certificate request.
+func FullRequestFingerprint(request *x509.CertificateRequest) string {
+	bytes := sha256.Sum256(request.Raw)
+	return hex.EncodeToString(bytes[:])
+}
+
+// IssuedBy returns true if a certificate was issued by an authority.  The authority key identifier of the certificate
+// must match the subject key identifier of the authority (or the issuer must match the subject of the authority if
+// either identifier is missing) and the signature of the certificate must verify with the key of the authority.
+func IssuedBy(certificate *x509.Certificate, authority *x509.Certificate) bool {
+
+	if len(certificate.AuthorityKeyId) > 0 && len(authority.SubjectKeyId) > 0 {
+		if !bytes.Equal(certificate.AuthorityKeyId, authority.SubjectKeyId) {
+			return false
+		}
+	} else if !bytes.Equal(certificate.RawIssuer, authority.RawSubject) {
+		return false
+	}
+
+	return certificate.CheckSignatureFrom(authority) == nil
+}
+
+// KeyAlgorithm returns the algorithm and strength of the public key of a certificate (e.g., RSA-4096).
+func KeyAlgorithm(certificate *x509.Certificate) string {
+	return keys.Algorithm(certificate.PublicKey)
+}
+
+// SignatureAlgorithm returns the algorithm used to sign a certificate (e.g., SHA256-RSA).
+func SignatureAlgorithm(certificate *x509.Certificate) string {
+	return certificate.SignatureAlgorithm.String()
 }
@@ -0,0 +1,300 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/pkg/errors"
+)
+
+// keyUsages defines the RFC 5280 names of the key usages in the order of their bits.
+var keyUsages = []string{
+	"digitalSignature", "contentCommitment", "keyEncipherment", "dataEncipherment", "keyAgreement", "keyCertSign",
+	"cRLSign", "encipherOnly", "decipherOnly",
+}
+
+// Details defines the details of a certificate for display.
+type Details struct {
+
+	// Alias defines the alias of the identity in its store.
+	Alias string `json:"alias,omitempty"`
+
+	// AuthorityKeyID defines the hex encoded authority key identifier.
+	AuthorityKeyID string `json:"authorityKeyId,omitempty"`
+
+	// BasicConstraints defines the basic constraints (nil if the extension is not present).
+	BasicConstraints *BasicConstraints `json:"basicConstraints,omitempty"`
+
+	// Chain defines the authorities of the certificate from the issuer to the root.
+	Chain []*Summary `json:"chain"`
+
+	// CRLDistributionPoints defines the URLs of the certificate revocation lists for the certificate.
+	CRLDistributionPoints []string `json:"crlDistributionPoints,omitempty"`
+
+	// DNSNames defines the DNS name SANs.
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// EmailAddresses defines the email address SANs.
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// ExtKeyUsage defines the names (or object identifiers if unknown) of the extended key usages.
+	ExtKeyUsage []string `json:"extKeyUsage,omitempty"`
+
+	// Fingerprint defines the fingerprint of the certificate.
+	Fingerprint string `json:"fingerprint"`
+
+	// IPAddresses defines the IP address SANs.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// Issuer defines the distinguished name of the issuer.
+	Issuer string `json:"issuer"`
+
+	// KeyAlgorithm defines the algorithm of the public key (i.e., ECDSA, ED25519 or RSA).
+	KeyAlgorithm string `json:"keyAlgorithm"`
+
+	// KeySize defines the size in bits of the public key.
+	KeySize int `json:"keySize"`
+
+	// KeyUsage defines the RFC 5280 names of the key usages.
+	KeyUsage []string `json:"keyUsage,omitempty"`
+
+	// Labels defines the key=value labels of the identity in its store.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// NotAfter defines the end of the validity window.
+	NotAfter time.Time `json:"notAfter"`
+
+	// NotBefore defines the start of the validity window.
+	NotBefore time.Time `json:"notBefore"`
+
+	// OCSPServers defines the URLs of the OCSP responders for the certificate.
+	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// SerialNumber defines the hex encoded serial number.
+	SerialNumber string `json:"serialNumber"`
+
+	// SignatureAlgorithm defines the algorithm used to sign the certificate.
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+
+	// SPKIPin defines the base64 encoded SHA256 hash of the subject public key info (see RFC 7469).
+	SPKIPin string `json:"spkiPin"`
+
+	// Subject defines the distinguished name of the subject.
+	Subject string `json:"subject"`
+
+	// SubjectKeyID defines the hex encoded subject key identifier.
+	SubjectKeyID string `json:"subjectKeyId,omitempty"`
+
+	// URIs defines the URI SANs.
+	URIs []string `json:"uris,omitempty"`
+}
+
+// BasicConstraints defines the basic constraints of a certificate.
+type BasicConstraints struct {
+
+	// CA defines whether the certificate is an authority.
+	CA bool `json:"ca"`
+
+	// MaxPathLen defines the maximum number of intermediate authorities below an authority (nil if unlimited).
+	MaxPathLen *int `json:"maxPathLen,omitempty"`
+}
+
+// Summary defines the identifying details of a certificate in a chain.
+type Summary struct {
+
+	// Fingerprint defines the fingerprint of the certificate.
+	Fingerprint string `json:"fingerprint"`
+
+	// NotAfter defines the end of the validity window.
+	NotAfter time.Time `json:"notAfter"`
+
+	// Subject defines the distinguished name of the subject.
+	Subject string `json:"subject"`
+}
+
+// Describe returns the details of a certificate with the provided chain of authorities (issuer first).
+func Describe(certificate *x509.Certificate, chain []*x509.Certificate) *Details {
+
+	pin := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+
+	details := &Details{
+		AuthorityKeyID:        hex.EncodeToString(certificate.AuthorityKeyId),
+		Chain:                 []*Summary{},
+		CRLDistributionPoints: certificate.CRLDistributionPoints,
+		DNSNames:              certificate.DNSNames,
+		EmailAddresses:        certificate.EmailAddresses,
+		Fingerprint:           Fingerprint(certificate),
+		Issuer:                certificate.Issuer.String(),
+		NotAfter:              certificate.NotAfter,
+		NotBefore:             certificate.NotBefore,
+		OCSPServers:           certificate.OCSPServer,
+		SerialNumber:          serials.Format(certificate.SerialNumber),
+		SignatureAlgorithm:    certificate.SignatureAlgorithm.String(),
+		SPKIPin:               base64.StdEncoding.EncodeToString(pin[:]),
+		Subject:               certificate.Subject.String(),
+		SubjectKeyID:          hex.EncodeToString(certificate.SubjectKeyId),
+	}
+
+	details.KeyAlgorithm, details.KeySize = keys.Parameters(certificate.PublicKey)
+
+	if certificate.BasicConstraintsValid {
+
+		details.BasicConstraints = &BasicConstraints{CA: certificate.IsCA}
+
+		if certificate.IsCA && (certificate.MaxPathLen > 0 || certificate.MaxPathLenZero) {
+			maxPathLen := certificate.MaxPathLen
+			details.BasicConstraints.MaxPathLen = &maxPathLen
+		}
+	}
+
+	for bit, name := range keyUsages {
+		if certificate.KeyUsage&(1<<uint(bit)) != 0 {
+			details.KeyUsage = append(details.KeyUsage, name)
+		}
+	}
+
+	for _, usage := range certificate.ExtKeyUsage {
+
+		details.ExtKeyUsage = append(details.ExtKeyUsage, ExtKeyUsageName(usage))
+	}
+
+	for _, usage := range certificate.UnknownExtKeyUsage {
+		details.ExtKeyUsage = append(details.ExtKeyUsage, usage.String())
+	}
+
+	for _, address := range certificate.IPAddresses {
+		details.IPAddresses = append(details.IPAddresses, address.String())
+	}
+
+	for _, uri := range certificate.URIs {
+		details.URIs = append(details.URIs, uri.String())
+	}
+
+	for _, authority := range chain {
+		details.Chain = append(details.Chain, &Summary{
+			Fingerprint: Fingerprint(authority),
+			NotAfter:    authority.NotAfter,
+			Subject:     authority.Subject.String(),
+		})
+	}
+
+	return details
+}
+
+// FormatDetails returns the details of a certificate in the provided output format (i.e., json or text).
+func FormatDetails(details *Details, output string) ([]byte, error) {
+
+	switch output {
+	case "json":
+
+		bytes, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding details as json")
+		}
+
+		return append(bytes, '\n'), nil
+
+	case "text":
+
+		var buffer bytes.Buffer
+
+		writer := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+		fmt.Fprintf(writer, "Fingerprint:\t%s\n", details.Fingerprint)
+
+		if details.Alias != "" {
+			fmt.Fprintf(writer, "Alias:\t%s\n", details.Alias)
+		}
+
+		if len(details.Labels) > 0 {
+			fmt.Fprintf(writer, "Labels:\t%s\n", labels.Format(details.Labels))
+		}
+
+		fmt.Fprintf(writer, "Subject:\t%s\n", details.Subject)
+		fmt.Fprintf(writer, "Issuer:\t%s\n", details.Issuer)
+		fmt.Fprintf(writer, "Serial Number:\t%s\n", details.SerialNumber)
+		fmt.Fprintf(writer, "Not Before:\t%s\n", details.NotBefore.Format(time.RFC3339))
+		fmt.Fprintf(writer, "Not After:\t%s\n", details.NotAfter.Format(time.RFC3339))
+		writeList(writer, "DNS Names", details.DNSNames)
+		writeList(writer, "Email Addresses", details.EmailAddresses)
+		writeList(writer, "IP Addresses", details.IPAddresses)
+		writeList(writer, "URIs", details.URIs)
+		writeList(writer, "Key Usage", details.KeyUsage)
+		writeList(writer, "Extended Key Usage", details.ExtKeyUsage)
+
+		if details.BasicConstraints != nil {
+
+			constraints := fmt.Sprintf("CA:%t", details.BasicConstraints.CA)
+			if details.BasicConstraints.MaxPathLen != nil {
+				constraints = fmt.Sprintf("%s, pathlen:%d", constraints, *details.BasicConstraints.MaxPathLen)
+			}
+
+			fmt.Fprintf(writer, "Basic Constraints:\t%s\n", constraints)
+		}
+
+		fmt.Fprintf(writer, "Key Algorithm:\t%s\n", details.KeyAlgorithm)
+		fmt.Fprintf(writer, "Key Size:\t%d\n", details.KeySize)
+		fmt.Fprintf(writer, "Signature Algorithm:\t%s\n", details.SignatureAlgorithm)
+		fmt.Fprintf(writer, "SPKI Pin:\tsha256/%s\n", details.SPKIPin)
+
+		if details.SubjectKeyID != "" {
+			fmt.Fprintf(writer, "Subject Key ID:\t%s\n", details.SubjectKeyID)
+		}
+
+		if details.AuthorityKeyID != "" {
+			fmt.Fprintf(writer, "Authority Key ID:\t%s\n", details.AuthorityKeyID)
+		}
+
+		writeList(writer, "CRL Distribution Points", details.CRLDistributionPoints)
+		writeList(writer, "OCSP Servers", details.OCSPServers)
+
+		for index, authority := range details.Chain {
+
+			label := ""
+			if index == 0 {
+				label = "Chain:"
+			}
+
+			fmt.Fprintf(writer, "%s\t%s  %s (expires %s)\n", label, authority.Fingerprint, authority.Subject, authority.NotAfter.Format(time.RFC3339))
+		}
+
+		writer.Flush()
+
+		return buffer.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("error parsing output [%s] must be one of [json, text]", output)
+	}
+}
+
+// writeList writes a labeled comma separated list unless the list is empty.
+func writeList(writer *tabwriter.Writer, label string, values []string) {
+	if len(values) > 0 {
+		fmt.Fprintf(writer, "%s:\t%s\n", label, strings.Join(values, ", "))
+	}
+}
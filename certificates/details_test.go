@@ -0,0 +1,58 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificates
+
+import (
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDetails(t *testing.T) {
+
+	Convey("Describe", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P384")
+		leaf := tests.MustGenerateLeaf(t, authority, keys.RSA, "")
+
+		details := Describe(leaf.Certificate, leaf.Authorities)
+
+		Convey("it describes the key", func() {
+			So(details.KeyAlgorithm, ShouldEqual, "RSA")
+			So(details.KeySize, ShouldEqual, 4096)
+		})
+
+		Convey("it names the usages", func() {
+			So(details.KeyUsage, ShouldResemble, []string{"digitalSignature"})
+			So(details.ExtKeyUsage, ShouldResemble, []string{"clientAuth", "serverAuth"})
+		})
+
+		Convey("it summarizes the chain", func() {
+			So(details.Chain, ShouldHaveLength, 1)
+			So(details.Chain[0].Fingerprint, ShouldEqual, Fingerprint(authority.Certificate))
+		})
+
+		Convey("when formatted with an unknown output", func() {
+
+			_, err := FormatDetails(details, "yaml")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificates
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// extKeyUsages defines the names of the extended key usages known to the x509 package.
+var extKeyUsages = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "any",
+	x509.ExtKeyUsageServerAuth:                     "serverAuth",
+	x509.ExtKeyUsageClientAuth:                     "clientAuth",
+	x509.ExtKeyUsageCodeSigning:                    "codeSigning",
+	x509.ExtKeyUsageEmailProtection:                "emailProtection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "ipsecEndSystem",
+	x509.ExtKeyUsageIPSECTunnel:                    "ipsecTunnel",
+	x509.ExtKeyUsageIPSECUser:                      "ipsecUser",
+	x509.ExtKeyUsageTimeStamping:                   "timeStamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSPSigning",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "microsoftServerGatedCrypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "netscapeServerGatedCrypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "microsoftCommercialCodeSigning",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "microsoftKernelCodeSigning",
+}
+
+// ExtKeyUsage returns the extended key usage with the provided name (e.g., serverAuth or clientAuth).
+func ExtKeyUsage(name string) (x509.ExtKeyUsage, error) {
+
+	names := make([]string, 0, len(extKeyUsages))
+	for usage, candidate := range extKeyUsages {
+
+		if strings.EqualFold(candidate, name) {
+			return usage, nil
+		}
+
+		names = append(names, candidate)
+	}
+
+	sort.Strings(names)
+
+	return x509.ExtKeyUsageAny, fmt.Errorf("error parsing extended key usage [%s] must be one of [%s]", name, strings.Join(names, ", "))
+}
+
+// ExtKeyUsageName returns the name of an extended key usage (e.g., serverAuth or clientAuth).
+func ExtKeyUsageName(usage x509.ExtKeyUsage) string {
+
+	name, found := extKeyUsages[usage]
+	if !found {
+		return fmt.Sprintf("unknown (%d)", usage)
+	}
+
+	return name
+}
@@ -16,9 +16,16 @@ package cmd
 
 import (
 	"github.com/greymatter-io/acert/cmd/authorities"
+	"github.com/greymatter-io/acert/cmd/check"
+	"github.com/greymatter-io/acert/cmd/contexts"
 	"github.com/greymatter-io/acert/cmd/leaves"
+	"github.com/greymatter-io/acert/cmd/ocsp"
+	"github.com/greymatter-io/acert/cmd/store"
+	"github.com/greymatter-io/acert/cmd/trash"
+	"github.com/greymatter-io/acert/cmd/verify"
 	"github.com/greymatter-io/acert/cmd/version"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Acert returns a command that creates and manages X.509 identites.
@@ -30,8 +37,26 @@ func Acert() *cobra.Command {
 		Long:  "A command line utility for creating and managing X.509 identities.",
 	}
 
+	command.PersistentFlags().String("context", "", "context whose home contains the stores (default $ACERT_CONTEXT or the current context)")
+	command.PersistentFlags().String("home", "", "directory containing the stores overriding any context (default $ACERT_HOME or ~/.acert)")
+	command.PersistentFlags().String("keyFile", "", "file containing the secret protecting encrypted stores (default $ACERT_KEY_FILE)")
+
+	viper.BindPFlag("context", command.PersistentFlags().Lookup("context"))
+	viper.BindEnv("context", "ACERT_CONTEXT")
+	viper.BindPFlag("home", command.PersistentFlags().Lookup("home"))
+	viper.BindEnv("home", "ACERT_HOME")
+	viper.BindPFlag("keyFile", command.PersistentFlags().Lookup("keyFile"))
+	viper.BindEnv("keyFile", "ACERT_KEY_FILE")
+	viper.BindEnv("passphrase", "ACERT_PASSPHRASE")
+
 	command.AddCommand(authorities.Command())
+	command.AddCommand(check.Command())
+	command.AddCommand(contexts.Command())
 	command.AddCommand(leaves.Command())
+	command.AddCommand(ocsp.Command())
+	command.AddCommand(store.Command())
+	command.AddCommand(trash.Command())
+	command.AddCommand(verify.Command())
 	command.AddCommand(version.Command())
 
 	return command
@@ -0,0 +1,65 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alias
+
+import (
+	"fmt"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that sets or removes the alias of an authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "alias FINGERPRINT [ALIAS]",
+		Short: "Set the alias of an authority",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("remove", command.Flags().Lookup("remove"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			if options.Remove == (len(args) == 2) {
+				return fmt.Errorf("error setting alias of [%s] must provide one of [ALIAS, --remove]", args[0])
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			alias := ""
+			if len(args) == 2 {
+				alias = args[1]
+			}
+
+			return stores.SetAlias(authorities, args[0], alias)
+		},
+	}
+
+	command.Flags().Bool("remove", false, "remove the alias of the authority")
+
+	return command
+}
@@ -12,14 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package export
+package alias
 
-// Options defines the options for the export command.
+// Options defines the options for the alias command.
 type Options struct {
 
-	// Type defines the type of artifact to export.
-	Type string `mapstructure:"type"`
-
-	// Format defines the format of the artifact to export.
-	Format string `mapstructure:"format"`
+	// Remove defines whether the alias of the authority is removed.
+	Remove bool `mapstructure:"remove"`
 }
@@ -0,0 +1,61 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"os"
+	"time"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/internal/checks"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that reports authorities that have expired or expire soon.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:          "check",
+		Short:        "Check authorities for expirations",
+		Long:         "Report authorities that have expired, expire within the window or outlive an authority in their chain that expires within the window, exiting non-zero if any are found.",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("output", command.Flags().Lookup("output"))
+			viper.BindPFlag("within", command.Flags().Lookup("within"))
+
+			var options checks.Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			return checks.Run(os.Stdout, options, time.Now(), checks.Target{Store: authorities, Type: "authority"})
+		},
+	}
+
+	command.Flags().StringP("output", "o", "text", "format of the findings [json, text]")
+	command.Flags().StringP("within", "w", "30d", "window in which expirations need attention (e.g., 30d or 72h)")
+
+	return command
+}
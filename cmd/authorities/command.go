@@ -15,11 +15,25 @@
 package authorities
 
 import (
+	"github.com/greymatter-io/acert/cmd/authorities/alias"
+	"github.com/greymatter-io/acert/cmd/authorities/check"
+	"github.com/greymatter-io/acert/cmd/authorities/complete"
 	"github.com/greymatter-io/acert/cmd/authorities/create"
+	"github.com/greymatter-io/acert/cmd/authorities/crl"
+	"github.com/greymatter-io/acert/cmd/authorities/delegate"
 	"github.com/greymatter-io/acert/cmd/authorities/delete"
 	"github.com/greymatter-io/acert/cmd/authorities/export"
+	"github.com/greymatter-io/acert/cmd/authorities/imports"
 	"github.com/greymatter-io/acert/cmd/authorities/issue"
+	"github.com/greymatter-io/acert/cmd/authorities/label"
+	"github.com/greymatter-io/acert/cmd/authorities/leaves"
 	"github.com/greymatter-io/acert/cmd/authorities/list"
+	"github.com/greymatter-io/acert/cmd/authorities/lookup"
+	"github.com/greymatter-io/acert/cmd/authorities/request"
+	"github.com/greymatter-io/acert/cmd/authorities/restore"
+	"github.com/greymatter-io/acert/cmd/authorities/show"
+	"github.com/greymatter-io/acert/cmd/authorities/sign"
+	"github.com/greymatter-io/acert/cmd/authorities/update"
 	"github.com/spf13/cobra"
 )
 
@@ -31,11 +45,25 @@ func Command() *cobra.Command {
 		Short: "Manage authorities",
 	}
 
+	command.AddCommand(alias.Command())
+	command.AddCommand(check.Command())
+	command.AddCommand(complete.Command())
 	command.AddCommand(create.Command())
+	command.AddCommand(crl.Command())
+	command.AddCommand(delegate.Command())
 	command.AddCommand(delete.Command())
 	command.AddCommand(export.Command())
+	command.AddCommand(imports.Command())
 	command.AddCommand(issue.Command())
+	command.AddCommand(label.Command())
+	command.AddCommand(leaves.Command())
 	command.AddCommand(list.Command())
+	command.AddCommand(lookup.Command())
+	command.AddCommand(request.Command())
+	command.AddCommand(restore.Command())
+	command.AddCommand(show.Command())
+	command.AddCommand(sign.Command())
+	command.AddCommand(update.Command())
 
 	return command
 }
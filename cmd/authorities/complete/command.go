@@ -0,0 +1,106 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package complete
+
+import (
+	"fmt"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/internal/chains"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that completes a request for an authority with the certificate issued for it.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "complete",
+		Short: "Complete an authority request",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("anchors", command.Flags().Lookup("anchors"))
+			viper.BindPFlag("certificate", command.Flags().Lookup("certificate"))
+			viper.BindPFlag("chain", command.Flags().Lookup("chain"))
+
+			var options chains.Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			certificate, chain, err := chains.Read(options)
+			if err != nil {
+				return err
+			}
+
+			if !certificate.IsCA {
+				return fmt.Errorf("error completing request [%s] is not a certificate authority", certificate.Subject.CommonName)
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			request, err := chains.Find(authorities, certificate)
+			if err != nil {
+				return err
+			}
+
+			if len(chain) == 0 {
+				chain, err = chains.Candidates(authorities)
+				if err != nil {
+					return err
+				}
+			}
+
+			trusted, err := chains.Anchors(options)
+			if err != nil {
+				return err
+			}
+
+			authority, err := request.Complete(certificate, chain, trusted)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := authorities.Upsert(authority)
+			if err != nil {
+				return err
+			}
+
+			err = authorities.DeleteRequest(certificates.RequestFingerprint(request.CertificateRequest))
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	command.Flags().String("anchors", "", "file containing the PEM encoded certificates trusted as roots in addition to the self signed authorities (- for stdin)")
+	command.Flags().StringP("certificate", "c", "", "file containing the PEM encoded certificate optionally followed by its chain (- for stdin)")
+	command.Flags().String("chain", "", "file containing the PEM encoded chain (- for stdin) (default the stored authorities)")
+
+	command.MarkFlagRequired("certificate")
+
+	return command
+}
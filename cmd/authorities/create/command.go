@@ -18,16 +18,21 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
-	"math/big"
 	"time"
 
+	"github.com/greymatter-io/acert/certificates"
 	"github.com/greymatter-io/acert/config"
-	"github.com/greymatter-io/nautls/identities"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// Command returns a command that issues a certificate.
+// Command returns a command that creates an intermediate authority and, unless an existing root is provided, the root
+// authority from which it is issued.
 func Command() *cobra.Command {
 
 	command := &cobra.Command{
@@ -36,15 +41,22 @@ func Command() *cobra.Command {
 		Args:  cobra.ExactArgs(0),
 		RunE: func(command *cobra.Command, args []string) error {
 
+			viper.BindPFlag("alias", command.Flags().Lookup("alias"))
 			viper.BindPFlag("commonName", command.Flags().Lookup("commonName"))
 			viper.BindPFlag("dnsNames", command.Flags().Lookup("dnsNames"))
 			viper.BindPFlag("country", command.Flags().Lookup("country"))
+			viper.BindPFlag("curve", command.Flags().Lookup("curve"))
 			viper.BindPFlag("expires", command.Flags().Lookup("expires"))
+			viper.BindPFlag("keySize", command.Flags().Lookup("keySize"))
+			viper.BindPFlag("keyType", command.Flags().Lookup("keyType"))
 			viper.BindPFlag("state", command.Flags().Lookup("state"))
+			viper.BindPFlag("labels", command.Flags().Lookup("labels"))
 			viper.BindPFlag("locality", command.Flags().Lookup("locality"))
 			viper.BindPFlag("organization", command.Flags().Lookup("organization"))
 			viper.BindPFlag("organizationalUnit", command.Flags().Lookup("organizationalUnit"))
 			viper.BindPFlag("postalCode", command.Flags().Lookup("postalCode"))
+			viper.BindPFlag("root", command.Flags().Lookup("root"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
 			viper.BindPFlag("streetAddress", command.Flags().Lookup("streetAddress"))
 
 			var options Options
@@ -54,6 +66,11 @@ func Command() *cobra.Command {
 				return err
 			}
 
+			labeled, err := labels.Parse(options.Labels)
+			if err != nil {
+				return err
+			}
+
 			template := identities.Template{
 				BasicConstraintsValid: true,
 				ExtKeyUsage:           []x509.ExtKeyUsage{},
@@ -61,7 +78,6 @@ func Command() *cobra.Command {
 				KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
 				NotAfter:              time.Now().Add(options.Expires),
 				NotBefore:             time.Now(),
-				SerialNumber:          big.NewInt(time.Now().Unix()),
 				DNSNames:              options.DNSNames,
 				Subject: pkix.Name{
 					CommonName:         fmt.Sprintf("%s (Root)", options.CommonName),
@@ -81,24 +97,67 @@ func Command() *cobra.Command {
 				template.Subject.PostalCode = []string{options.PostalCode}
 			}
 
-			root, err := identities.Self(template)
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			if options.Alias != "" {
+
+				err = stores.AvailableAlias(authorities, "", options.Alias)
+				if err != nil {
+					return err
+				}
+			}
+
+			var root *identities.Identity
+
+			if options.Root == "" {
+				root, err = createRoot(authorities, template, options)
+			} else {
+				root, err = fetchRoot(authorities, options.Root)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			// An existing root may expire before the intermediate would.
+			template.Limit(root.Certificate)
+
+			template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, root.Key.Public())
 			if err != nil {
 				return err
 			}
 
 			template.Subject.CommonName = fmt.Sprintf("%s (Intermediate)", options.CommonName)
 
-			intermediate, err := root.Issue(template)
+			template.SerialNumber, err = serials.Generate()
 			if err != nil {
 				return err
 			}
 
-			authorities, err := config.Authorities()
+			intermediateKey, err := keys.Generate(options.KeyType, options.KeySize, options.Curve)
 			if err != nil {
 				return err
 			}
 
-			fingerprint, err := authorities.Upsert(intermediate)
+			err = stores.ApplyMetadata(authorities, certificates.Fingerprint(root.Certificate), &template)
+			if err != nil {
+				return err
+			}
+
+			intermediate, err := root.Issue(template, intermediateKey)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := stores.Store(authorities, certificates.Fingerprint(root.Certificate), authorities, intermediate)
+			if err != nil {
+				return err
+			}
+
+			err = stores.Annotate(authorities, fingerprint, options.Alias, labeled)
 			if err != nil {
 				return err
 			}
@@ -109,16 +168,74 @@ func Command() *cobra.Command {
 		},
 	}
 
+	command.Flags().String("alias", "", "unique name that may be used in place of the fingerprint of the authority")
 	command.Flags().StringP("commonName", "n", "Acert", "common name for the authority")
 	command.Flags().StringSliceP("dnsNames", "d", []string{"Acert"}, "list of SANs for the authority")
 	command.Flags().StringP("country", "c", "US", "two letter country code for the authority")
-	command.Flags().DurationP("expires", "e", (time.Hour * 24 * 3650), "expiration time for the authority")
+	command.Flags().DurationP("expires", "e", (time.Hour * 24 * 3650), "expiration time for the authority (the intermediate is limited to the expiration of the root)")
+	command.Flags().StringP("keyType", "k", "rsa", "type of key for the authority [ecdsa, ed25519, rsa]")
+	command.Flags().Int("keySize", 0, "size in bits for rsa keys [2048, 3072, 4096, 8192] (default 4096)")
+	command.Flags().String("curve", "P256", "elliptic curve for ecdsa keys [P256, P384, P521]")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the authority (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
 	command.Flags().StringP("state", "s", "Virginia", "state for the authority")
+	command.Flags().StringSlice("labels", []string{}, "list of key=value labels for the authority (e.g., env=staging,team=mesh)")
 	command.Flags().StringP("locality", "l", "Alexandria", "locality for the authority")
 	command.Flags().StringP("organization", "o", "Decipher Technology Studios", "organization for the authority")
 	command.Flags().StringP("organizationalUnit", "u", "Engineering", "organizational unit for the authority")
 	command.Flags().StringP("postalCode", "p", "", "postal code for the authority")
+	command.Flags().StringP("root", "r", "", "fingerprint of an existing root to issue the intermediate from instead of creating a root")
 	command.Flags().StringP("streetAddress", "a", "", "street address for the authority")
 
 	return command
 }
+
+// createRoot creates and stores a root authority for a template.
+func createRoot(authorities stores.IdentityStore, template identities.Template, options Options) (*identities.Identity, error) {
+
+	key, err := keys.Generate(options.KeyType, options.KeySize, options.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	template.SerialNumber, err = serials.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := identities.Self(template, key)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := authorities.Upsert(root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorities.UpdateMetadata(fingerprint, &stores.Metadata{Root: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// fetchRoot returns an existing root authority.
+func fetchRoot(authorities stores.IdentityStore, fingerprint string) (*identities.Identity, error) {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metadata.Root {
+		return nil, fmt.Errorf("error issuing intermediate authority [%s] is not a root", fingerprint)
+	}
+
+	return authorities.Fetch(fingerprint)
+}
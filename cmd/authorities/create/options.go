@@ -21,18 +21,33 @@ import (
 // Options defines the options for the create command.
 type Options struct {
 
+	// Alias defines a unique name that may be used in place of the fingerprint of the authority.
+	Alias string `mapstructure:"alias"`
+
 	// CommonName defines the common name for an authority.
 	CommonName string `mapstructure:"commonName"`
 
 	// Country defines the country for an authority.
 	Country string `mapstructure:"country"`
 
+	// Curve defines the elliptic curve for an authority key when the key type is ecdsa.
+	Curve string `mapstructure:"curve"`
+
 	// DNSNames defines the subject alternative names for a certificate.
 	DNSNames []string `mapstructure:"dnsNames"`
 
 	// Expires defines the duration for which an authority is valid.
 	Expires time.Duration `mapstructure:"expires"`
 
+	// KeySize defines the size in bits for an authority key when the key type is rsa.
+	KeySize int `mapstructure:"keySize"`
+
+	// KeyType defines the type of key for an authority (i.e., ecdsa, ed25519 or rsa).
+	KeyType string `mapstructure:"keyType"`
+
+	// Labels defines the key=value labels for the authority.
+	Labels []string `mapstructure:"labels"`
+
 	// Locality defines the city or county for an authority.
 	Locality string `mapstructure:"locality"`
 
@@ -45,6 +60,12 @@ type Options struct {
 	// PostalCode defines the postal code for an authority.
 	PostalCode string `mapstructure:"postalCode"`
 
+	// Root defines the fingerprint of an existing root from which to issue an intermediate.
+	Root string `mapstructure:"root"`
+
+	// SignatureAlgorithm defines the algorithm used to sign an authority (e.g., SHA384-RSA).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+
 	// State defines the state or province for an authority.
 	State string `mapstructure:"state"`
 
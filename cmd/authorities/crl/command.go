@@ -0,0 +1,115 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crl
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/revocations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that generates the certificate revocation list of an authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "crl FINGERPRINT",
+		Short: "Generate the certificate revocation list of an authority",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("format", command.Flags().Lookup("format"))
+			viper.BindPFlag("nextUpdate", command.Flags().Lookup("nextUpdate"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			authority, err := authorities.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			algorithm, err := keys.SignatureAlgorithm(options.SignatureAlgorithm, authority.Key.Public())
+			if err != nil {
+				return err
+			}
+
+			fingerprint := certificates.Fingerprint(authority.Certificate)
+
+			metadata, err := authorities.FetchMetadata(fingerprint)
+			if err != nil {
+				return err
+			}
+
+			entries, err := revocations.Entries(metadata.Revocations)
+			if err != nil {
+				return err
+			}
+
+			// The CRL number must increase monotonically for each list issued by the authority (see RFC 5280).
+			metadata.CRLNumber++
+
+			thisUpdate := time.Now()
+
+			list, err := authority.RevocationList(entries, big.NewInt(metadata.CRLNumber), thisUpdate, thisUpdate.Add(options.NextUpdate), algorithm)
+			if err != nil {
+				return err
+			}
+
+			output, err := encoding.EncodeRevocationList(list, options.Format)
+			if err != nil {
+				return err
+			}
+
+			err = authorities.UpdateMetadata(fingerprint, metadata)
+			if err != nil {
+				return err
+			}
+
+			if encoding.Binary(options.Format) {
+				_, err = os.Stdout.Write(output)
+				return err
+			}
+
+			fmt.Print(string(output))
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("format", "f", "pem", "the format of the certificate revocation list [der, pem]")
+	command.Flags().Duration("nextUpdate", (time.Hour * 24 * 7), "duration after which the next certificate revocation list will be issued")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the certificate revocation list (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
+
+	return command
+}
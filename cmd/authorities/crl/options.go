@@ -0,0 +1,32 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crl
+
+import (
+	"time"
+)
+
+// Options defines the options for the crl command.
+type Options struct {
+
+	// Format defines the format of the certificate revocation list (i.e., der or pem).
+	Format string `mapstructure:"format"`
+
+	// NextUpdate defines the duration after which a new certificate revocation list will be issued.
+	NextUpdate time.Duration `mapstructure:"nextUpdate"`
+
+	// SignatureAlgorithm defines the algorithm used to sign the certificate revocation list (e.g., SHA384-RSA).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+}
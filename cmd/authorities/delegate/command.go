@@ -0,0 +1,271 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegate
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that issues a subordinate authority from an existing authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "delegate FINGERPRINT",
+		Short: "Issue a subordinate authority",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("alias", command.Flags().Lookup("alias"))
+			viper.BindPFlag("commonName", command.Flags().Lookup("commonName"))
+			viper.BindPFlag("dnsNames", command.Flags().Lookup("dnsNames"))
+			viper.BindPFlag("country", command.Flags().Lookup("country"))
+			viper.BindPFlag("curve", command.Flags().Lookup("curve"))
+			viper.BindPFlag("excludedDNSDomains", command.Flags().Lookup("excludedDNSDomains"))
+			viper.BindPFlag("excludedEmailAddresses", command.Flags().Lookup("excludedEmailAddresses"))
+			viper.BindPFlag("excludedIPRanges", command.Flags().Lookup("excludedIPRanges"))
+			viper.BindPFlag("expires", command.Flags().Lookup("expires"))
+			viper.BindPFlag("keySize", command.Flags().Lookup("keySize"))
+			viper.BindPFlag("keyType", command.Flags().Lookup("keyType"))
+			viper.BindPFlag("state", command.Flags().Lookup("state"))
+			viper.BindPFlag("labels", command.Flags().Lookup("labels"))
+			viper.BindPFlag("locality", command.Flags().Lookup("locality"))
+			viper.BindPFlag("maxPathLen", command.Flags().Lookup("maxPathLen"))
+			viper.BindPFlag("organization", command.Flags().Lookup("organization"))
+			viper.BindPFlag("organizationalUnit", command.Flags().Lookup("organizationalUnit"))
+			viper.BindPFlag("permittedDNSDomains", command.Flags().Lookup("permittedDNSDomains"))
+			viper.BindPFlag("permittedEmailAddresses", command.Flags().Lookup("permittedEmailAddresses"))
+			viper.BindPFlag("permittedIPRanges", command.Flags().Lookup("permittedIPRanges"))
+			viper.BindPFlag("postalCode", command.Flags().Lookup("postalCode"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
+			viper.BindPFlag("streetAddress", command.Flags().Lookup("streetAddress"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			labeled, err := labels.Parse(options.Labels)
+			if err != nil {
+				return err
+			}
+
+			permittedIPRanges, err := parseIPRanges(options.PermittedIPRanges)
+			if err != nil {
+				return errors.Wrap(err, "error parsing permitted IP ranges")
+			}
+
+			excludedIPRanges, err := parseIPRanges(options.ExcludedIPRanges)
+			if err != nil {
+				return errors.Wrap(err, "error parsing excluded IP ranges")
+			}
+
+			serial, err := serials.Generate()
+			if err != nil {
+				return err
+			}
+
+			template := identities.Template{
+				BasicConstraintsValid:   true,
+				DNSNames:                options.DNSNames,
+				ExcludedDNSDomains:      options.ExcludedDNSDomains,
+				ExcludedEmailAddresses:  options.ExcludedEmailAddresses,
+				ExcludedIPRanges:        excludedIPRanges,
+				ExtKeyUsage:             []x509.ExtKeyUsage{},
+				IsCA:                    true,
+				KeyUsage:                x509.KeyUsageCRLSign | x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+				NotBefore:               time.Now(),
+				PermittedDNSDomains:     options.PermittedDNSDomains,
+				PermittedEmailAddresses: options.PermittedEmailAddresses,
+				PermittedIPRanges:       permittedIPRanges,
+				SerialNumber:            serial,
+				Subject: pkix.Name{
+					CommonName:         options.CommonName,
+					Country:            []string{options.Country},
+					Locality:           []string{options.Locality},
+					Organization:       []string{options.Organization},
+					OrganizationalUnit: []string{options.OrganizationalUnit},
+					Province:           []string{options.State},
+				},
+			}
+
+			// Name constraints must be marked critical (see RFC 5280 section 4.2.1.10).
+			template.PermittedDNSDomainsCritical = len(template.PermittedDNSDomains) > 0 ||
+				len(template.PermittedEmailAddresses) > 0 ||
+				len(template.PermittedIPRanges) > 0 ||
+				len(template.ExcludedDNSDomains) > 0 ||
+				len(template.ExcludedEmailAddresses) > 0 ||
+				len(template.ExcludedIPRanges) > 0
+
+			if options.StreetAddress != "" {
+				template.Subject.StreetAddress = []string{options.StreetAddress}
+			}
+
+			if options.PostalCode != "" {
+				template.Subject.PostalCode = []string{options.PostalCode}
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			if options.Alias != "" {
+
+				err = stores.AvailableAlias(authorities, "", options.Alias)
+				if err != nil {
+					return err
+				}
+			}
+
+			authority, err := authorities.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			template.MaxPathLen, template.MaxPathLenZero, err = maxPathLen(authority.Certificate, options.MaxPathLen)
+			if err != nil {
+				return errors.Wrapf(err, "error delegating authority [%s]", args[0])
+			}
+
+			// The subordinate authority may not outlive the authority from which it is issued.
+			template.NotAfter = template.NotBefore.Add(options.Expires)
+			template.Limit(authority.Certificate)
+
+			template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, authority.Key.Public())
+			if err != nil {
+				return err
+			}
+
+			key, err := keys.Generate(options.KeyType, options.KeySize, options.Curve)
+			if err != nil {
+				return err
+			}
+
+			err = stores.ApplyMetadata(authorities, certificates.Fingerprint(authority.Certificate), &template)
+			if err != nil {
+				return err
+			}
+
+			delegate, err := authority.Issue(template, key)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := stores.Store(authorities, certificates.Fingerprint(authority.Certificate), authorities, delegate)
+			if err != nil {
+				return err
+			}
+
+			err = stores.Annotate(authorities, fingerprint, options.Alias, labeled)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	command.Flags().String("alias", "", "unique name that may be used in place of the fingerprint of the authority")
+	command.Flags().StringP("commonName", "n", "Acert (Delegate)", "common name for the authority")
+	command.Flags().StringSliceP("dnsNames", "d", []string{}, "list of SANs for the authority")
+	command.Flags().StringP("country", "c", "US", "two letter country code for the authority")
+	command.Flags().DurationP("expires", "e", (time.Hour * 24 * 3650), "maximum expiration time for the authority (limited to the expiration of the issuing authority)")
+	command.Flags().StringSlice("excludedDNSDomains", []string{}, "list of DNS domains the authority may not issue certificates for")
+	command.Flags().StringSlice("excludedEmailAddresses", []string{}, "list of email addresses or domains the authority may not issue certificates for")
+	command.Flags().StringSlice("excludedIPRanges", []string{}, "list of CIDR ranges the authority may not issue certificates for")
+	command.Flags().StringP("keyType", "k", "rsa", "type of key for the authority [ecdsa, ed25519, rsa]")
+	command.Flags().Int("keySize", 0, "size in bits for rsa keys [2048, 3072, 4096, 8192] (default 4096)")
+	command.Flags().String("curve", "P256", "elliptic curve for ecdsa keys [P256, P384, P521]")
+	command.Flags().Int("maxPathLen", -1, "maximum number of authorities that may follow the authority in a chain (negative for one less than the issuing authority or unlimited)")
+	command.Flags().StringSlice("permittedDNSDomains", []string{}, "list of DNS domains the authority may issue certificates for")
+	command.Flags().StringSlice("permittedEmailAddresses", []string{}, "list of email addresses or domains the authority may issue certificates for")
+	command.Flags().StringSlice("permittedIPRanges", []string{}, "list of CIDR ranges the authority may issue certificates for")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the authority (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
+	command.Flags().StringP("state", "s", "Virginia", "state for the authority")
+	command.Flags().StringSlice("labels", []string{}, "list of key=value labels for the authority (e.g., env=staging,team=mesh)")
+	command.Flags().StringP("locality", "l", "Alexandria", "locality for the authority")
+	command.Flags().StringP("organization", "o", "Decipher Technology Studios", "organization for the authority")
+	command.Flags().StringP("organizationalUnit", "u", "Engineering", "organizational unit for the authority")
+	command.Flags().StringP("postalCode", "p", "", "postal code for the authority")
+	command.Flags().StringP("streetAddress", "a", "", "street address for the authority")
+
+	return command
+}
+
+// maxPathLen returns the path length constraint for an authority issued from an issuer given the requested maximum
+// path length (i.e., a negative value requests the largest path length permitted by the issuer).
+func maxPathLen(issuer *x509.Certificate, requested int) (int, bool, error) {
+
+	limit := -1
+	if issuer.MaxPathLen > 0 || issuer.MaxPathLenZero {
+		limit = issuer.MaxPathLen
+	}
+
+	if limit == 0 {
+		return 0, false, fmt.Errorf("issuer path length constraint does not permit subordinate authorities")
+	}
+
+	if requested < 0 {
+
+		if limit > 0 {
+			return limit - 1, limit == 1, nil
+		}
+
+		return -1, false, nil
+	}
+
+	if limit > 0 && requested >= limit {
+		return 0, false, fmt.Errorf("error parsing max path length [%d] must be less than [%d]", requested, limit)
+	}
+
+	return requested, requested == 0, nil
+}
+
+// parseIPRanges returns the networks for a list of CIDR ranges.
+func parseIPRanges(ranges []string) ([]*net.IPNet, error) {
+
+	networks := make([]*net.IPNet, len(ranges))
+
+	for index, value := range ranges {
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing IP range [%s] must be in CIDR notation (e.g., 10.0.0.0/8)", value)
+		}
+
+		networks[index] = network
+	}
+
+	return networks, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegate
+
+import (
+	"time"
+)
+
+// Options defines the options for the delegate command.
+type Options struct {
+
+	// Alias defines a unique name that may be used in place of the fingerprint of the authority.
+	Alias string `mapstructure:"alias"`
+
+	// CommonName defines the common name for an authority.
+	CommonName string `mapstructure:"commonName"`
+
+	// Country defines the country for an authority.
+	Country string `mapstructure:"country"`
+
+	// Curve defines the elliptic curve for an authority key when the key type is ecdsa.
+	Curve string `mapstructure:"curve"`
+
+	// DNSNames defines the subject alternative names for an authority.
+	DNSNames []string `mapstructure:"dnsNames"`
+
+	// ExcludedDNSDomains defines the DNS domains the authority may not issue certificates for.
+	ExcludedDNSDomains []string `mapstructure:"excludedDNSDomains"`
+
+	// ExcludedEmailAddresses defines the email addresses, domains or mailboxes the authority may not issue
+	// certificates for.
+	ExcludedEmailAddresses []string `mapstructure:"excludedEmailAddresses"`
+
+	// ExcludedIPRanges defines the CIDR ranges the authority may not issue certificates for.
+	ExcludedIPRanges []string `mapstructure:"excludedIPRanges"`
+
+	// Expires defines the maximum duration for which an authority is valid.
+	Expires time.Duration `mapstructure:"expires"`
+
+	// KeySize defines the size in bits for an authority key when the key type is rsa.
+	KeySize int `mapstructure:"keySize"`
+
+	// KeyType defines the type of key for an authority (i.e., ecdsa, ed25519 or rsa).
+	KeyType string `mapstructure:"keyType"`
+
+	// Labels defines the key=value labels for the authority.
+	Labels []string `mapstructure:"labels"`
+
+	// Locality defines the city or county for an authority.
+	Locality string `mapstructure:"locality"`
+
+	// MaxPathLen defines the maximum number of authorities that may follow an authority in a chain (i.e., a negative
+	// value is one less than the issuing authority or unlimited).
+	MaxPathLen int `mapstructure:"maxPathLen"`
+
+	// Organization defines the organization for an authority.
+	Organization string `mapstructure:"organization"`
+
+	// OrganizationalUnit defines the organization unit for an authority.
+	OrganizationalUnit string `mapstructure:"organizationalUnit"`
+
+	// PermittedDNSDomains defines the DNS domains the authority may issue certificates for.
+	PermittedDNSDomains []string `mapstructure:"permittedDNSDomains"`
+
+	// PermittedEmailAddresses defines the email addresses, domains or mailboxes the authority may issue certificates
+	// for.
+	PermittedEmailAddresses []string `mapstructure:"permittedEmailAddresses"`
+
+	// PermittedIPRanges defines the CIDR ranges the authority may issue certificates for.
+	PermittedIPRanges []string `mapstructure:"permittedIPRanges"`
+
+	// PostalCode defines the postal code for an authority.
+	PostalCode string `mapstructure:"postalCode"`
+
+	// SignatureAlgorithm defines the algorithm used to sign an authority (e.g., SHA384-RSA).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+
+	// State defines the state or province for an authority.
+	State string `mapstructure:"state"`
+
+	// StreetAddress defines the street address for an authority.
+	StreetAddress string `mapstructure:"streetAddress"`
+}
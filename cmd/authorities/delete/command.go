@@ -15,32 +15,139 @@
 package delete
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/deletions"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/internal/prompts"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Command returns a command that deletes an authority.
 func Command() *cobra.Command {
 
 	command := &cobra.Command{
-		Use:   "delete FINGERPRINT",
-		Short: "Delete an authority",
-		Args:  cobra.ExactArgs(1),
+		Use:          "delete FINGERPRINT",
+		Short:        "Delete an authority",
+		Long:         "Delete an authority.  Deleting an authority that issued live (i.e., neither expired nor revoked) authorities or leaves is refused unless --cascade or --orphan is provided.  Revoking the authorities and leaves issued by the authority (--cascade=revoke) writes its final certificate revocation list to stdout.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
 		RunE: func(command *cobra.Command, args []string) error {
 
+			viper.BindPFlag("cascade", command.Flags().Lookup("cascade"))
+			viper.BindPFlag("dryRun", command.Flags().Lookup("dry-run"))
+			viper.BindPFlag("orphan", command.Flags().Lookup("orphan"))
+			viper.BindPFlag("yes", command.Flags().Lookup("yes"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			if options.Cascade != "" && options.Cascade != deletions.Delete && options.Cascade != deletions.Revoke {
+				return fmt.Errorf("error parsing cascade [%s] must be one of [%s, %s]", options.Cascade, deletions.Delete, deletions.Revoke)
+			}
+
+			if options.Cascade != "" && options.Orphan {
+				return fmt.Errorf("error deleting authority [%s] --cascade and --orphan may not be used together", args[0])
+			}
+
 			authorities, err := config.Authorities()
 			if err != nil {
 				return err
 			}
 
-			err = authorities.Delete(args[0])
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			records, err := authorities.ListRecords()
+			if err != nil {
+				return err
+			}
+
+			authority, err := stores.FindRecord(records, args[0])
+			if err != nil {
+				return err
+			}
+
+			fingerprint := authority.Fingerprint
+
+			dependents, err := stores.Dependents(authorities, leaves, fingerprint, time.Now())
+			if err != nil {
+				return err
+			}
+
+			live := []string{}
+			for _, dependent := range dependents {
+				if dependent.Live {
+					live = append(live, dependent.Fingerprint)
+				}
+			}
+
+			actions := deletions.Plan(fingerprint, authority.Certificate, dependents, options.Cascade)
+
+			if options.DryRun {
+				deletions.Write(os.Stdout, actions)
+			}
+
+			if len(live) > 0 && options.Cascade == "" && !options.Orphan {
+				return fmt.Errorf("error deleting authority [%s] live authorities or leaves [%s] depend on it (use --cascade, --cascade=revoke or --orphan)", fingerprint, strings.Join(live, ", "))
+			}
+
+			if options.DryRun {
+				return nil
+			}
+
+			if !options.Yes {
+
+				deletions.Write(os.Stderr, actions)
+
+				confirmed, err := prompts.Confirm(fmt.Sprintf("Delete authority [%s] affecting %d authorities and leaves", fingerprint, len(dependents)))
+				if err != nil {
+					return errors.Wrap(err, "error confirming deletion (use --yes to delete without confirmation)")
+				}
+
+				if !confirmed {
+					return fmt.Errorf("error deleting authority [%s] not confirmed", fingerprint)
+				}
+			}
+
+			list, err := deletions.Apply(authorities, leaves, fingerprint, actions, "deleted by authorities delete", time.Now())
+			if err != nil {
+				return err
+			}
+
+			if list == nil {
+				return nil
+			}
+
+			output, err := encoding.EncodeRevocationList(list, "pem")
 			if err != nil {
 				return err
 			}
 
+			fmt.Print(string(output))
+
 			return nil
 		},
 	}
 
+	command.Flags().String("cascade", "", "delete (the default) or revoke (e.g., --cascade=revoke) the authorities and leaves issued by the authority [delete, revoke]")
+	command.Flags().Lookup("cascade").NoOptDefVal = deletions.Delete
+	command.Flags().Bool("dry-run", false, "list the authority, authorities and leaves that would be affected without deleting anything")
+	command.Flags().Bool("orphan", false, "delete the authority even though live authorities and leaves issued by it will be left without an issuing authority")
+	command.Flags().BoolP("yes", "y", false, "delete without prompting for confirmation")
+
 	return command
 }
@@ -0,0 +1,32 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delete
+
+// Options defines the options for the delete command.
+type Options struct {
+
+	// Cascade defines what happens to the authorities and leaves issued by the authority (i.e., delete or revoke).
+	Cascade string `mapstructure:"cascade"`
+
+	// DryRun defines whether to list what would be affected without deleting anything.
+	DryRun bool `mapstructure:"dryRun"`
+
+	// Orphan defines whether to delete the authority leaving the authorities and leaves it issued without an issuing
+	// authority.
+	Orphan bool `mapstructure:"orphan"`
+
+	// Yes defines whether to delete without prompting for confirmation.
+	Yes bool `mapstructure:"yes"`
+}
@@ -16,10 +16,11 @@ package export
 
 import (
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/greymatter-io/acert/config"
 	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/internal/exports"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -34,9 +35,12 @@ func Command() *cobra.Command {
 		RunE: func(command *cobra.Command, args []string) error {
 
 			viper.BindPFlag("format", command.Flags().Lookup("format"))
+			viper.BindPFlag("friendlyName", command.Flags().Lookup("friendlyName"))
+			viper.BindPFlag("password", command.Flags().Lookup("password"))
+			viper.BindEnv("password", "ACERT_PASSWORD")
 			viper.BindPFlag("type", command.Flags().Lookup("type"))
 
-			var options Options
+			var options exports.Options
 
 			err := viper.Unmarshal(&options)
 			if err != nil {
@@ -53,25 +57,25 @@ func Command() *cobra.Command {
 				return err
 			}
 
-			switch strings.ToLower(options.Type) {
-			case "authority":
-				fmt.Println(strings.Join(encoding.PEMEncodeCertificates(authority.Authorities), ""))
-				break
-			case "certificate":
-				fmt.Println(encoding.PEMEncodeCertificate(authority.Certificate))
-				break
-			case "key":
-				fmt.Println(encoding.PEMEncodeKey(authority.Key))
-				break
-			default:
-				return fmt.Errorf("error parsing type [%s] must be one of [authority, certificate, key]", options.Type)
+			output, err := exports.Encode(authority, options)
+			if err != nil {
+				return err
+			}
+
+			if encoding.Binary(options.Format) {
+				_, err = os.Stdout.Write(output)
+				return err
 			}
 
+			fmt.Println(string(output))
+
 			return nil
 		},
 	}
 
-	command.Flags().StringP("format", "f", "pem", "the format of the exported authority")
+	command.Flags().StringP("format", "f", "pem", "the format of the exported authority [der, p12, pem, pkcs1, pkcs8]")
+	command.Flags().String("friendlyName", "", "the friendly name (alias) of p12 trust store exports (default the common name of each certificate)")
+	command.Flags().StringP("password", "P", "", "the password protecting p12 exports (default $ACERT_PASSWORD or a prompt)")
 	command.Flags().StringP("type", "t", "certificate", "the type of values to be exported [authority, certificate, key]")
 
 	return command
@@ -15,14 +15,20 @@
 package issue
 
 import (
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
-	"math/big"
 	"time"
 
+	"github.com/greymatter-io/acert/certificates"
 	"github.com/greymatter-io/acert/config"
-	"github.com/greymatter-io/nautls/identities"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -36,15 +42,22 @@ func Command() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(command *cobra.Command, args []string) error {
 
+			viper.BindPFlag("alias", command.Flags().Lookup("alias"))
 			viper.BindPFlag("commonName", command.Flags().Lookup("commonName"))
 			viper.BindPFlag("dnsNames", command.Flags().Lookup("dnsNames"))
 			viper.BindPFlag("country", command.Flags().Lookup("country"))
+			viper.BindPFlag("curve", command.Flags().Lookup("curve"))
 			viper.BindPFlag("expires", command.Flags().Lookup("expires"))
+			viper.BindPFlag("keySize", command.Flags().Lookup("keySize"))
+			viper.BindPFlag("keyType", command.Flags().Lookup("keyType"))
 			viper.BindPFlag("state", command.Flags().Lookup("state"))
+			viper.BindPFlag("labels", command.Flags().Lookup("labels"))
 			viper.BindPFlag("locality", command.Flags().Lookup("locality"))
+			viper.BindPFlag("ocspSigning", command.Flags().Lookup("ocspSigning"))
 			viper.BindPFlag("organization", command.Flags().Lookup("organization"))
 			viper.BindPFlag("organizationalUnit", command.Flags().Lookup("organizationalUnit"))
 			viper.BindPFlag("postalCode", command.Flags().Lookup("postalCode"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
 			viper.BindPFlag("streetAddress", command.Flags().Lookup("streetAddress"))
 
 			var options Options
@@ -54,6 +67,16 @@ func Command() *cobra.Command {
 				return err
 			}
 
+			labeled, err := labels.Parse(options.Labels)
+			if err != nil {
+				return err
+			}
+
+			serial, err := serials.Generate()
+			if err != nil {
+				return err
+			}
+
 			template := identities.Template{
 				BasicConstraintsValid: true,
 				DNSNames:              options.DNSNames,
@@ -61,7 +84,7 @@ func Command() *cobra.Command {
 				KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 				NotAfter:              time.Now().Add(options.Expires),
 				NotBefore:             time.Now(),
-				SerialNumber:          big.NewInt(time.Now().Unix()),
+				SerialNumber:          serial,
 				Subject: pkix.Name{
 					CommonName:         options.CommonName,
 					Country:            []string{options.Country},
@@ -80,27 +103,72 @@ func Command() *cobra.Command {
 				template.Subject.PostalCode = []string{options.PostalCode}
 			}
 
+			// Delegated OCSP responders are not checked for revocation (see RFC 6960 section 4.2.2.2.1).
+			if options.OCSPSigning {
+				template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}
+				template.ExtraExtensions = []pkix.Extension{{Id: certificates.OIDOCSPNoCheck, Value: asn1.NullBytes}}
+				template.KeyUsage = x509.KeyUsageDigitalSignature
+			}
+
 			authorities, err := config.Authorities()
 			if err != nil {
 				return err
 			}
 
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			if options.Alias != "" {
+
+				err = stores.AvailableAlias(leaves, "", options.Alias)
+				if err != nil {
+					return err
+				}
+			}
+
 			authority, err := authorities.Fetch(args[0])
 			if err != nil {
 				return err
 			}
 
-			leaf, err := authority.Issue(template)
+			template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, authority.Key.Public())
 			if err != nil {
 				return err
 			}
 
-			leaves, err := config.Leaves()
+			key, err := keys.Generate(options.KeyType, options.KeySize, options.Curve)
+			if err != nil {
+				return err
+			}
+
+			// Key encipherment is only meaningful for keys capable of key transport (i.e., RSA).
+			if _, ok := key.Public().(*rsa.PublicKey); !ok {
+				template.KeyUsage &^= x509.KeyUsageKeyEncipherment
+			}
+
+			err = stores.ApplyMetadata(authorities, certificates.Fingerprint(authority.Certificate), &template)
+			if err != nil {
+				return err
+			}
+
+			leaf, err := authority.Issue(template, key)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := stores.Store(authorities, certificates.Fingerprint(authority.Certificate), leaves, leaf)
+			if err != nil {
+				return err
+			}
+
+			err = stores.RecordIssuer(leaves, fingerprint, certificates.Fingerprint(authority.Certificate))
 			if err != nil {
 				return err
 			}
 
-			fingerprint, err := leaves.Upsert(leaf)
+			err = stores.Annotate(leaves, fingerprint, options.Alias, labeled)
 			if err != nil {
 				return err
 			}
@@ -111,12 +179,19 @@ func Command() *cobra.Command {
 		},
 	}
 
+	command.Flags().String("alias", "", "unique name that may be used in place of the fingerprint of the leaf")
 	command.Flags().StringP("commonName", "n", "Acert", "common name for the authority")
 	command.Flags().StringSliceP("dnsNames", "d", []string{"Acert"}, "list of SANs for the authority")
 	command.Flags().StringP("country", "c", "US", "two letter country code for the authority")
 	command.Flags().DurationP("expires", "e", (time.Hour * 24 * 3650), "expiration time for the authority")
+	command.Flags().StringP("keyType", "k", "rsa", "type of key for the leaf [ecdsa, ed25519, rsa]")
+	command.Flags().Int("keySize", 0, "size in bits for rsa keys [2048, 3072, 4096, 8192] (default 4096)")
+	command.Flags().String("curve", "P256", "elliptic curve for ecdsa keys [P256, P384, P521]")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the leaf (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
 	command.Flags().StringP("state", "s", "Virginia", "state for the authority")
+	command.Flags().StringSlice("labels", []string{}, "list of key=value labels for the leaf (e.g., env=staging,team=mesh)")
 	command.Flags().StringP("locality", "l", "Alexandria", "locality for the authority")
+	command.Flags().Bool("ocspSigning", false, "issue the leaf for signing OCSP responses on behalf of the authority (see acert ocsp serve)")
 	command.Flags().StringP("organization", "o", "Decipher Technology Studios", "organization for the authority")
 	command.Flags().StringP("organizationalUnit", "u", "Engineering", "organizational unit for the authority")
 	command.Flags().StringP("postalCode", "p", "", "postal code for the authority")
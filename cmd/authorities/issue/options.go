@@ -21,21 +21,39 @@ import (
 // Options defines the options for the issue command.
 type Options struct {
 
+	// Alias defines a unique name that may be used in place of the fingerprint of the leaf.
+	Alias string `mapstructure:"alias"`
+
 	// CommonName defines the common name for an certificate.
 	CommonName string `mapstructure:"commonName"`
 
 	// Country defines the country for an certificate.
 	Country string `mapstructure:"country"`
 
+	// Curve defines the elliptic curve for a certificate key when the key type is ecdsa.
+	Curve string `mapstructure:"curve"`
+
 	// DNSNames defines the subject alternative names for a certificate.
 	DNSNames []string `mapstructure:"dnsNames"`
 
 	// Expires defines the duration for which an certificate is valid.
 	Expires time.Duration `mapstructure:"expires"`
 
+	// KeySize defines the size in bits for a certificate key when the key type is rsa.
+	KeySize int `mapstructure:"keySize"`
+
+	// KeyType defines the type of key for a certificate (i.e., ecdsa, ed25519 or rsa).
+	KeyType string `mapstructure:"keyType"`
+
+	// Labels defines the key=value labels for the leaf.
+	Labels []string `mapstructure:"labels"`
+
 	// Locality defines the city or county for an certificate.
 	Locality string `mapstructure:"locality"`
 
+	// OCSPSigning defines whether a certificate is issued for signing OCSP responses on behalf of its authority.
+	OCSPSigning bool `mapstructure:"ocspSigning"`
+
 	// Organization defines the organization for an certificate.
 	Organization string `mapstructure:"organization"`
 
@@ -45,6 +63,9 @@ type Options struct {
 	// PostalCode defines the postal code for an certificate.
 	PostalCode string `mapstructure:"postalCode"`
 
+	// SignatureAlgorithm defines the algorithm used to sign a certificate (e.g., SHA384-RSA).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+
 	// State defines the state or province for an certificate.
 	State string `mapstructure:"state"`
 
@@ -0,0 +1,92 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaves
+
+import (
+	"os"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/listings"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that lists the leaves issued by an authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "leaves FINGERPRINT",
+		Short: "List the leaves issued by an authority",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("output", command.Flags().Lookup("output"))
+			viper.BindPFlag("sortBy", command.Flags().Lookup("sort-by"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			authority, err := authorities.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			entries, err := listings.List(leaves, authorities, "leaf")
+			if err != nil {
+				return err
+			}
+
+			entries, err = listings.Filter(entries, listings.Options{Authority: certificates.Fingerprint(authority.Certificate)}, time.Now())
+			if err != nil {
+				return err
+			}
+
+			err = listings.Sort(entries, options.SortBy)
+			if err != nil {
+				return err
+			}
+
+			bytes, err := listings.Format(entries, options.Output)
+			if err != nil {
+				return err
+			}
+
+			os.Stdout.Write(bytes)
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("output", "o", "text", "format of the list [json, yaml, text, wide, custom-columns=HEADER:field,...]")
+	command.Flags().String("sort-by", "fingerprint", "field the list is ordered by [created, expiration, fingerprint, name]")
+
+	return command
+}
@@ -0,0 +1,25 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaves
+
+// Options defines the options for the leaves command.
+type Options struct {
+
+	// Output defines the format of the list (i.e., json, yaml, text, wide or custom-columns=HEADER:field,...).
+	Output string `mapstructure:"output"`
+
+	// SortBy defines the field the list is ordered by (i.e., created, expiration, fingerprint or name).
+	SortBy string `mapstructure:"sortBy"`
+}
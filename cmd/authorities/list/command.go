@@ -15,11 +15,15 @@
 package list
 
 import (
-	"fmt"
+	"os"
+	"time"
 
-	"github.com/greymatter-io/acert/certificates"
 	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/listings"
+	"github.com/greymatter-io/acert/stores"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Command returns a command that lists the authorities.
@@ -30,28 +34,100 @@ func Command() *cobra.Command {
 		Short: "List the authorities",
 		RunE: func(command *cobra.Command, args []string) error {
 
+			viper.BindPFlag("authority", command.Flags().Lookup("authority"))
+			viper.BindPFlag("expired", command.Flags().Lookup("expired"))
+			viper.BindPFlag("intermediates", command.Flags().Lookup("intermediates"))
+			viper.BindPFlag("nameRegex", command.Flags().Lookup("name-regex"))
+			viper.BindPFlag("output", command.Flags().Lookup("output"))
+			viper.BindPFlag("roots", command.Flags().Lookup("roots"))
+			viper.BindPFlag("selector", command.Flags().Lookup("selector"))
+			viper.BindPFlag("sortBy", command.Flags().Lookup("sort-by"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			selector, err := labels.Parse(options.Selector)
+			if err != nil {
+				return err
+			}
+
 			authorities, err := config.Authorities()
 			if err != nil {
 				return err
 			}
 
-			identities, err := authorities.List()
+			authority := ""
+			if options.Authority != "" {
+
+				records, err := authorities.ListRecords()
+				if err != nil {
+					return err
+				}
+
+				record, err := stores.FindRecord(records, options.Authority)
+				if err != nil {
+					return err
+				}
+
+				authority = record.Fingerprint
+			}
+
+			entries, err := listings.List(authorities, authorities, "authority")
 			if err != nil {
 				return err
 			}
 
-			for _, identity := range identities {
+			if options.Roots != options.Intermediates {
 
-				expiration := certificates.Expiration(identity.Certificate)
-				fingerprint := certificates.Fingerprint(identity.Certificate)
-				name := certificates.CommonName(identity.Certificate)
+				filtered := []*listings.Entry{}
 
-				fmt.Printf("%s\t%s\t%v\n", fingerprint, name, expiration)
+				for _, entry := range entries {
+					if entry.Root == options.Roots {
+						filtered = append(filtered, entry)
+					}
+				}
+
+				entries = filtered
+			}
+
+			entries, err = listings.Filter(entries, listings.Options{
+				Authority: authority,
+				Expired:   options.Expired,
+				NameRegex: options.NameRegex,
+				Selector:  selector,
+			}, time.Now())
+			if err != nil {
+				return err
 			}
 
+			err = listings.Sort(entries, options.SortBy)
+			if err != nil {
+				return err
+			}
+
+			bytes, err := listings.Format(entries, options.Output)
+			if err != nil {
+				return err
+			}
+
+			os.Stdout.Write(bytes)
+
 			return nil
 		},
 	}
 
+	command.Flags().StringP("authority", "a", "", "list only the authorities issued by the authority with the fingerprint")
+	command.Flags().Bool("expired", false, "list only the expired authorities")
+	command.Flags().Bool("intermediates", false, "list only the intermediate authorities")
+	command.Flags().String("name-regex", "", "list only the authorities with common names matching the regular expression")
+	command.Flags().StringP("output", "o", "text", "format of the list [json, yaml, text, wide, custom-columns=HEADER:field,...]")
+	command.Flags().Bool("roots", false, "list only the root authorities")
+	command.Flags().StringSliceP("selector", "l", []string{}, "list only the authorities with the labels (e.g., env=staging,team=mesh)")
+	command.Flags().String("sort-by", "fingerprint", "field the list is ordered by [created, expiration, fingerprint, name]")
+
 	return command
 }
@@ -0,0 +1,57 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lookup
+
+import (
+	"fmt"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that looks up a certificate issued by an authority by its serial number.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "lookup FINGERPRINT SERIAL",
+		Short: "Look up a certificate issued by an authority by its serial number",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			serial, err := serials.Parse(args[1])
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := stores.Lookup(authorities, args[0], serial)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	return command
+}
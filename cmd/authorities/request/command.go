@@ -0,0 +1,132 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"os"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that requests an authority from an external authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "request",
+		Short: "Request an authority from an external authority",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("commonName", command.Flags().Lookup("commonName"))
+			viper.BindPFlag("dnsNames", command.Flags().Lookup("dnsNames"))
+			viper.BindPFlag("country", command.Flags().Lookup("country"))
+			viper.BindPFlag("curve", command.Flags().Lookup("curve"))
+			viper.BindPFlag("keySize", command.Flags().Lookup("keySize"))
+			viper.BindPFlag("keyType", command.Flags().Lookup("keyType"))
+			viper.BindPFlag("state", command.Flags().Lookup("state"))
+			viper.BindPFlag("locality", command.Flags().Lookup("locality"))
+			viper.BindPFlag("organization", command.Flags().Lookup("organization"))
+			viper.BindPFlag("organizationalUnit", command.Flags().Lookup("organizationalUnit"))
+			viper.BindPFlag("postalCode", command.Flags().Lookup("postalCode"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
+			viper.BindPFlag("streetAddress", command.Flags().Lookup("streetAddress"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			template := identities.Template{
+				BasicConstraintsValid: true,
+				DNSNames:              options.DNSNames,
+				IsCA:                  true,
+				KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+				Subject: pkix.Name{
+					CommonName:         options.CommonName,
+					Country:            []string{options.Country},
+					Locality:           []string{options.Locality},
+					Organization:       []string{options.Organization},
+					OrganizationalUnit: []string{options.OrganizationalUnit},
+					Province:           []string{options.State},
+				},
+			}
+
+			if options.StreetAddress != "" {
+				template.Subject.StreetAddress = []string{options.StreetAddress}
+			}
+
+			if options.PostalCode != "" {
+				template.Subject.PostalCode = []string{options.PostalCode}
+			}
+
+			key, err := keys.Generate(options.KeyType, options.KeySize, options.Curve)
+			if err != nil {
+				return err
+			}
+
+			template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, key.Public())
+			if err != nil {
+				return err
+			}
+
+			request, err := identities.NewRequest(template, key)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := authorities.UpsertRequest(request)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(os.Stderr, fingerprint)
+
+			fmt.Print(encoding.PEMEncodeCertificateRequest(request.CertificateRequest))
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("commonName", "n", "Acert (Intermediate)", "common name for the authority")
+	command.Flags().StringSliceP("dnsNames", "d", []string{"Acert"}, "list of SANs for the authority")
+	command.Flags().StringP("country", "c", "US", "two letter country code for the authority")
+	command.Flags().StringP("keyType", "k", "rsa", "type of key for the authority [ecdsa, ed25519, rsa]")
+	command.Flags().Int("keySize", 0, "size in bits for rsa keys [2048, 3072, 4096, 8192] (default 4096)")
+	command.Flags().String("curve", "P256", "elliptic curve for ecdsa keys [P256, P384, P521]")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the request (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
+	command.Flags().StringP("state", "s", "Virginia", "state for the authority")
+	command.Flags().StringP("locality", "l", "Alexandria", "locality for the authority")
+	command.Flags().StringP("organization", "o", "Decipher Technology Studios", "organization for the authority")
+	command.Flags().StringP("organizationalUnit", "u", "Engineering", "organizational unit for the authority")
+	command.Flags().StringP("postalCode", "p", "", "postal code for the authority")
+	command.Flags().StringP("streetAddress", "a", "", "street address for the authority")
+
+	return command
+}
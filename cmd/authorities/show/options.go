@@ -12,14 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package export
+package show
 
-// Options defines the options for the export command.
+// Options defines the options for the show command.
 type Options struct {
 
-	// Type defines the type of artifact to export.
-	Type string `mapstructure:"type"`
-
-	// Format defines the format of the artifact to export.
-	Format string `mapstructure:"format"`
+	// Output defines the format of the details (i.e., json or text).
+	Output string `mapstructure:"output"`
 }
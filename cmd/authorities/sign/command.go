@@ -0,0 +1,267 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/files"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that signs a certificate signing request.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "sign FINGERPRINT",
+		Short: "Sign a certificate signing request",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("alias", command.Flags().Lookup("alias"))
+			viper.BindPFlag("commonName", command.Flags().Lookup("commonName"))
+			viper.BindPFlag("country", command.Flags().Lookup("country"))
+			viper.BindPFlag("csr", command.Flags().Lookup("csr"))
+			viper.BindPFlag("dnsNames", command.Flags().Lookup("dnsNames"))
+			viper.BindPFlag("emailAddresses", command.Flags().Lookup("emailAddresses"))
+			viper.BindPFlag("expires", command.Flags().Lookup("expires"))
+			viper.BindPFlag("ipAddresses", command.Flags().Lookup("ipAddresses"))
+			viper.BindPFlag("state", command.Flags().Lookup("state"))
+			viper.BindPFlag("labels", command.Flags().Lookup("labels"))
+			viper.BindPFlag("locality", command.Flags().Lookup("locality"))
+			viper.BindPFlag("organization", command.Flags().Lookup("organization"))
+			viper.BindPFlag("organizationalUnit", command.Flags().Lookup("organizationalUnit"))
+			viper.BindPFlag("postalCode", command.Flags().Lookup("postalCode"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
+			viper.BindPFlag("streetAddress", command.Flags().Lookup("streetAddress"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			labeled, err := labels.Parse(options.Labels)
+			if err != nil {
+				return err
+			}
+
+			bytes, err := files.Read(options.CSR)
+			if err != nil {
+				return err
+			}
+
+			request, err := encoding.PEMDecodeCertificateRequest(bytes)
+			if err != nil {
+				return errors.Wrapf(err, "error decoding certificate request from [%s]", options.CSR)
+			}
+
+			err = request.CheckSignature()
+			if err != nil {
+				return errors.Wrapf(err, "error verifying certificate request from [%s]", options.CSR)
+			}
+
+			serial, err := serials.Generate()
+			if err != nil {
+				return err
+			}
+
+			template := identities.Template{
+				BasicConstraintsValid: true,
+				DNSNames:              request.DNSNames,
+				EmailAddresses:        request.EmailAddresses,
+				ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+				IPAddresses:           request.IPAddresses,
+				KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+				NotAfter:              time.Now().Add(options.Expires),
+				NotBefore:             time.Now(),
+				SerialNumber:          serial,
+				Subject:               request.Subject,
+				URIs:                  request.URIs,
+			}
+
+			// The requested subject and subject alternative names are replaced by those explicitly provided.
+			flags := command.Flags()
+
+			if flags.Changed("commonName") {
+				template.Subject.CommonName = options.CommonName
+			}
+
+			if flags.Changed("country") {
+				template.Subject.Country = []string{options.Country}
+			}
+
+			if flags.Changed("locality") {
+				template.Subject.Locality = []string{options.Locality}
+			}
+
+			if flags.Changed("organization") {
+				template.Subject.Organization = []string{options.Organization}
+			}
+
+			if flags.Changed("organizationalUnit") {
+				template.Subject.OrganizationalUnit = []string{options.OrganizationalUnit}
+			}
+
+			if flags.Changed("postalCode") {
+				template.Subject.PostalCode = []string{options.PostalCode}
+			}
+
+			if flags.Changed("state") {
+				template.Subject.Province = []string{options.State}
+			}
+
+			if flags.Changed("streetAddress") {
+				template.Subject.StreetAddress = []string{options.StreetAddress}
+			}
+
+			if flags.Changed("dnsNames") {
+				template.DNSNames = options.DNSNames
+			}
+
+			if flags.Changed("emailAddresses") {
+				template.EmailAddresses = options.EmailAddresses
+			}
+
+			if flags.Changed("ipAddresses") {
+				template.IPAddresses, err = parseIPAddresses(options.IPAddresses)
+				if err != nil {
+					return err
+				}
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			if options.Alias != "" {
+
+				err = stores.AvailableAlias(leaves, "", options.Alias)
+				if err != nil {
+					return err
+				}
+			}
+
+			authority, err := authorities.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			template.SignatureAlgorithm, err = keys.SignatureAlgorithm(options.SignatureAlgorithm, authority.Key.Public())
+			if err != nil {
+				return err
+			}
+
+			// Key encipherment is only meaningful for keys capable of key transport (i.e., RSA).
+			if _, ok := request.PublicKey.(*rsa.PublicKey); !ok {
+				template.KeyUsage &^= x509.KeyUsageKeyEncipherment
+			}
+
+			err = stores.ApplyMetadata(authorities, certificates.Fingerprint(authority.Certificate), &template)
+			if err != nil {
+				return err
+			}
+
+			certificate, err := authority.Sign(template, request.PublicKey)
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := stores.Store(authorities, certificates.Fingerprint(authority.Certificate), leaves, certificate)
+			if err != nil {
+				return err
+			}
+
+			err = stores.RecordIssuer(leaves, fingerprint, certificates.Fingerprint(authority.Certificate))
+			if err != nil {
+				return err
+			}
+
+			err = stores.Annotate(leaves, fingerprint, options.Alias, labeled)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(os.Stderr, fingerprint)
+
+			fmt.Print(encoding.PEMEncodeCertificate(certificate.Certificate))
+			fmt.Print(strings.Join(encoding.PEMEncodeCertificates(certificate.Authorities), ""))
+
+			return nil
+		},
+	}
+
+	command.Flags().String("alias", "", "unique name that may be used in place of the fingerprint of the leaf")
+	command.Flags().String("csr", "", "file containing the PEM encoded certificate signing request (- for stdin)")
+	command.Flags().StringP("commonName", "n", "", "common name for the leaf (default the requested common name)")
+	command.Flags().StringSliceP("dnsNames", "d", []string{}, "list of DNS SANs for the leaf (default the requested DNS SANs)")
+	command.Flags().StringSlice("emailAddresses", []string{}, "list of email SANs for the leaf (default the requested email SANs)")
+	command.Flags().StringSlice("ipAddresses", []string{}, "list of IP SANs for the leaf (default the requested IP SANs)")
+	command.Flags().StringP("country", "c", "", "two letter country code for the leaf (default the requested country)")
+	command.Flags().DurationP("expires", "e", (time.Hour * 24 * 3650), "expiration time for the leaf")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the leaf (e.g., SHA384-RSA, SHA256-RSAPSS, ECDSA-SHA384)")
+	command.Flags().StringP("state", "s", "", "state for the leaf (default the requested state)")
+	command.Flags().StringSlice("labels", []string{}, "list of key=value labels for the leaf (e.g., env=staging,team=mesh)")
+	command.Flags().StringP("locality", "l", "", "locality for the leaf (default the requested locality)")
+	command.Flags().StringP("organization", "o", "", "organization for the leaf (default the requested organization)")
+	command.Flags().StringP("organizationalUnit", "u", "", "organizational unit for the leaf (default the requested organizational unit)")
+	command.Flags().StringP("postalCode", "p", "", "postal code for the leaf (default the requested postal code)")
+	command.Flags().StringP("streetAddress", "a", "", "street address for the leaf (default the requested street address)")
+
+	command.MarkFlagRequired("csr")
+
+	return command
+}
+
+// parseIPAddresses returns the IP addresses for a list of strings.
+func parseIPAddresses(values []string) ([]net.IP, error) {
+
+	addresses := make([]net.IP, len(values))
+
+	for index, value := range values {
+
+		address := net.ParseIP(value)
+		if address == nil {
+			return nil, fmt.Errorf("error parsing IP address [%s]", value)
+		}
+
+		addresses[index] = address
+	}
+
+	return addresses, nil
+}
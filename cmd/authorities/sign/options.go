@@ -0,0 +1,71 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"time"
+)
+
+// Options defines the options for the sign command.
+type Options struct {
+
+	// Alias defines a unique name that may be used in place of the fingerprint of the leaf.
+	Alias string `mapstructure:"alias"`
+
+	// CommonName overrides the requested common name for a certificate.
+	CommonName string `mapstructure:"commonName"`
+
+	// Country overrides the requested country for a certificate.
+	Country string `mapstructure:"country"`
+
+	// CSR defines the path to the PEM encoded certificate signing request.
+	CSR string `mapstructure:"csr"`
+
+	// DNSNames overrides the requested DNS subject alternative names for a certificate.
+	DNSNames []string `mapstructure:"dnsNames"`
+
+	// EmailAddresses overrides the requested email subject alternative names for a certificate.
+	EmailAddresses []string `mapstructure:"emailAddresses"`
+
+	// Expires defines the duration for which a certificate is valid.
+	Expires time.Duration `mapstructure:"expires"`
+
+	// IPAddresses overrides the requested IP subject alternative names for a certificate.
+	IPAddresses []string `mapstructure:"ipAddresses"`
+
+	// Labels defines the key=value labels for the leaf.
+	Labels []string `mapstructure:"labels"`
+
+	// Locality overrides the requested city or county for a certificate.
+	Locality string `mapstructure:"locality"`
+
+	// Organization overrides the requested organization for a certificate.
+	Organization string `mapstructure:"organization"`
+
+	// OrganizationalUnit overrides the requested organization unit for a certificate.
+	OrganizationalUnit string `mapstructure:"organizationalUnit"`
+
+	// PostalCode overrides the requested postal code for a certificate.
+	PostalCode string `mapstructure:"postalCode"`
+
+	// SignatureAlgorithm defines the algorithm used to sign a certificate (e.g., SHA384-RSA).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+
+	// State overrides the requested state or province for a certificate.
+	State string `mapstructure:"state"`
+
+	// StreetAddress overrides the requested street address for a certificate.
+	StreetAddress string `mapstructure:"streetAddress"`
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that updates the settings of an authority.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "update FINGERPRINT",
+		Short: "Update the settings of an authority",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("crlURL", command.Flags().Lookup("crlURL"))
+			viper.BindPFlag("ocspURL", command.Flags().Lookup("ocspURL"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			metadata, err := authorities.FetchMetadata(args[0])
+			if err != nil {
+				return err
+			}
+
+			if command.Flags().Changed("crlURL") {
+
+				err = validate(options.CRLURL)
+				if err != nil {
+					return err
+				}
+
+				metadata.CRLURL = options.CRLURL
+			}
+
+			if command.Flags().Changed("ocspURL") {
+
+				err = validate(options.OCSPURL)
+				if err != nil {
+					return err
+				}
+
+				metadata.OCSPURL = options.OCSPURL
+			}
+
+			return authorities.UpdateMetadata(args[0], metadata)
+		},
+	}
+
+	command.Flags().String("crlURL", "", "URL of the certificate revocation list embedded in the certificates issued by the authority (empty to remove)")
+	command.Flags().String("ocspURL", "", "URL of the OCSP responder embedded in the certificates issued by the authority (empty to remove)")
+
+	return command
+}
+
+// validate returns an error if a non-empty value is not an absolute URL.
+func validate(value string) error {
+
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || !parsed.IsAbs() {
+		return fmt.Errorf("error parsing URL [%s] must be an absolute URL", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+// Options defines the options for the update command.
+type Options struct {
+
+	// CRLURL defines the URL of the certificate revocation list embedded in the certificates issued by an authority.
+	CRLURL string `mapstructure:"crlURL"`
+
+	// OCSPURL defines the URL of the OCSP responder embedded in the certificates issued by an authority.
+	OCSPURL string `mapstructure:"ocspURL"`
+}
@@ -0,0 +1,40 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contexts
+
+import (
+	"github.com/greymatter-io/acert/cmd/contexts/create"
+	"github.com/greymatter-io/acert/cmd/contexts/delete"
+	"github.com/greymatter-io/acert/cmd/contexts/list"
+	"github.com/greymatter-io/acert/cmd/contexts/use"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that manages contexts.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "context",
+		Short: "Manage contexts",
+		Long:  "Manage contexts.  A context names a home directory containing authority and leaf stores so that commands may switch between sets of stores (e.g., per project).",
+	}
+
+	command.AddCommand(create.Command())
+	command.AddCommand(delete.Command())
+	command.AddCommand(list.Command())
+	command.AddCommand(use.Command())
+
+	return command
+}
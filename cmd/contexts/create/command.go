@@ -0,0 +1,67 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package create
+
+import (
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that creates a context.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a context",
+		Long:  "Create a context with the home provided by --home (or ACERT_HOME), e.g., acert context create dev --home ./pki.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("use", command.Flags().Lookup("use"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			contexts, err := config.ReadContexts()
+			if err != nil {
+				return err
+			}
+
+			err = contexts.Add(args[0], options.Home)
+			if err != nil {
+				return err
+			}
+
+			if options.Use {
+
+				err = contexts.Use(args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			return config.WriteContexts(contexts)
+		},
+	}
+
+	command.Flags().Bool("use", false, "make the context current")
+
+	return command
+}
@@ -0,0 +1,53 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that lists the contexts.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the contexts marking the current context with *",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			contexts, err := config.ReadContexts()
+			if err != nil {
+				return err
+			}
+
+			for _, name := range contexts.Names() {
+
+				current := ""
+				if name == contexts.Current {
+					current = "*"
+				}
+
+				fmt.Printf("%s\t%s\t%s\n", current, name, contexts.Contexts[name].Home)
+			}
+
+			return nil
+		},
+	}
+
+	return command
+}
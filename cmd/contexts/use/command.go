@@ -0,0 +1,46 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package use
+
+import (
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that makes a context current.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "use NAME",
+		Short: "Make a context current",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			contexts, err := config.ReadContexts()
+			if err != nil {
+				return err
+			}
+
+			err = contexts.Use(args[0])
+			if err != nil {
+				return err
+			}
+
+			return config.WriteContexts(contexts)
+		},
+	}
+
+	return command
+}
@@ -15,9 +15,20 @@
 package leaves
 
 import (
+	"github.com/greymatter-io/acert/cmd/leaves/alias"
+	"github.com/greymatter-io/acert/cmd/leaves/check"
+	"github.com/greymatter-io/acert/cmd/leaves/complete"
 	"github.com/greymatter-io/acert/cmd/leaves/delete"
 	"github.com/greymatter-io/acert/cmd/leaves/export"
+	"github.com/greymatter-io/acert/cmd/leaves/imports"
+	"github.com/greymatter-io/acert/cmd/leaves/label"
+	"github.com/greymatter-io/acert/cmd/leaves/lineage"
 	"github.com/greymatter-io/acert/cmd/leaves/list"
+	"github.com/greymatter-io/acert/cmd/leaves/renew"
+	"github.com/greymatter-io/acert/cmd/leaves/request"
+	"github.com/greymatter-io/acert/cmd/leaves/restore"
+	"github.com/greymatter-io/acert/cmd/leaves/revoke"
+	"github.com/greymatter-io/acert/cmd/leaves/show"
 	"github.com/spf13/cobra"
 )
 
@@ -29,9 +40,20 @@ func Command() *cobra.Command {
 		Short: "Manage leaves",
 	}
 
+	command.AddCommand(alias.Command())
+	command.AddCommand(check.Command())
+	command.AddCommand(complete.Command())
 	command.AddCommand(delete.Command())
 	command.AddCommand(export.Command())
+	command.AddCommand(imports.Command())
+	command.AddCommand(label.Command())
+	command.AddCommand(lineage.Command())
 	command.AddCommand(list.Command())
+	command.AddCommand(renew.Command())
+	command.AddCommand(request.Command())
+	command.AddCommand(restore.Command())
+	command.AddCommand(revoke.Command())
+	command.AddCommand(show.Command())
 
 	return command
 }
@@ -16,6 +16,7 @@ package delete
 
 import (
 	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/stores"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +34,7 @@ func Command() *cobra.Command {
 				return err
 			}
 
-			err = leaves.Delete(args[0])
+			err = stores.Remove(leaves, args[0], "deleted by leaves delete")
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,152 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imports
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/chains"
+	"github.com/greymatter-io/acert/internal/files"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that imports an existing leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "import",
+		Short: "Import a leaf",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("anchors", command.Flags().Lookup("anchors"))
+			viper.BindPFlag("certificate", command.Flags().Lookup("certificate"))
+			viper.BindPFlag("chain", command.Flags().Lookup("chain"))
+			viper.BindPFlag("key", command.Flags().Lookup("key"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			certificate, chain, key, err := read(options)
+			if err != nil {
+				return err
+			}
+
+			if len(chain) == 0 {
+				chain, err = chains.Candidates(authorities)
+				if err != nil {
+					return err
+				}
+			}
+
+			trusted, err := chains.Anchors(options.Options)
+			if err != nil {
+				return err
+			}
+
+			chain, err = identities.Chain(certificate, chain, trusted)
+			if err != nil {
+				return errors.Wrapf(err, "error importing leaf [%s]", certificate.Subject.CommonName)
+			}
+
+			leaf := identities.NewIdentity(chain, certificate, key)
+
+			err = leaf.Verify()
+			if err != nil {
+				return errors.Wrapf(err, "error importing leaf [%s]", certificate.Subject.CommonName)
+			}
+
+			fingerprint, err := leaves.Upsert(leaf)
+			if err != nil {
+				return err
+			}
+
+			stored, err := authorities.ListRecords()
+			if err != nil {
+				return err
+			}
+
+			issuer := stores.MatchIssuer(stored, leaf.Certificate, leaf.Authorities, "")
+			if issuer != "" {
+
+				err = stores.RecordIssuer(leaves, fingerprint, issuer)
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	command.Flags().String("anchors", "", "file containing the PEM encoded certificates trusted as roots in addition to the self signed authorities (- for stdin)")
+	command.Flags().StringP("certificate", "c", "", "file containing the PEM encoded certificate optionally followed by its chain (- for stdin)")
+	command.Flags().String("chain", "", "file containing the PEM encoded chain (- for stdin) (default the stored authorities)")
+	command.Flags().StringP("key", "k", "", "file containing the PEM encoded PKCS #1, PKCS #8 or EC key (- for stdin)")
+
+	command.MarkFlagRequired("certificate")
+	command.MarkFlagRequired("key")
+
+	return command
+}
+
+// read returns the certificate, chain and key from the files defined by the options.
+func read(options Options) (*x509.Certificate, []*x509.Certificate, crypto.Signer, error) {
+
+	if options.Key == files.Stdin && options.Options.Stdin() {
+		return nil, nil, nil, fmt.Errorf("error reading identity stdin may only be used for one file")
+	}
+
+	certificate, chain, err := chains.Read(options.Options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	bytes, err := files.Read(options.Key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key, err := encoding.PEMDecodeKey(bytes)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "error decoding key from [%s]", options.Key)
+	}
+
+	return certificate, chain, key, nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imports
+
+import "github.com/greymatter-io/acert/internal/chains"
+
+// Options defines the options for the import command.
+type Options struct {
+
+	// Options defines the paths to the PEM encoded certificate, chain and anchors of the leaf.
+	chains.Options `mapstructure:",squash"`
+
+	// Key defines the path to the PEM encoded key of the leaf.
+	Key string `mapstructure:"key"`
+}
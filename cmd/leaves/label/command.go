@@ -0,0 +1,62 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"strings"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that adds, updates or removes the labels of a leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "label FINGERPRINT KEY=VALUE... [KEY-...]",
+		Short: "Label a leaf",
+		Long:  "Add or update the labels of a leaf provided as KEY=VALUE and remove the labels provided as KEY- (e.g., env=staging team-).",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			pairs := []string{}
+			removed := []string{}
+
+			for _, arg := range args[1:] {
+				if strings.HasSuffix(arg, "-") && !strings.Contains(arg, "=") {
+					removed = append(removed, strings.TrimSuffix(arg, "-"))
+				} else {
+					pairs = append(pairs, arg)
+				}
+			}
+
+			added, err := labels.Parse(pairs)
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			return stores.SetLabels(leaves, args[0], added, removed)
+		},
+	}
+
+	return command
+}
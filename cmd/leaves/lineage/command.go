@@ -0,0 +1,67 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lineage
+
+import (
+	"os"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/listings"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that lists the renewals of a leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "lineage FINGERPRINT",
+		Short: "List the renewals of a leaf",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			lineage, err := stores.Lineage(leaves, args[0])
+			if err != nil {
+				return err
+			}
+
+			renewals, err := listings.Select(leaves, authorities, "leaf", lineage)
+			if err != nil {
+				return err
+			}
+
+			bytes, err := listings.Format(renewals, "text")
+			if err != nil {
+				return err
+			}
+
+			os.Stdout.Write(bytes)
+
+			return nil
+		},
+	}
+
+	return command
+}
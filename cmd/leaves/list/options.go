@@ -0,0 +1,37 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+// Options defines the options for the list command.
+type Options struct {
+
+	// Authority defines the fingerprint of the authority that must have issued the listed leaves.
+	Authority string `mapstructure:"authority"`
+
+	// Expired defines whether to list only the expired leaves.
+	Expired bool `mapstructure:"expired"`
+
+	// NameRegex defines the regular expression the common names of the listed leaves must match.
+	NameRegex string `mapstructure:"nameRegex"`
+
+	// Output defines the format of the list (i.e., json, yaml, text, wide or custom-columns=HEADER:field,...).
+	Output string `mapstructure:"output"`
+
+	// Selector defines the key=value labels a leaf must have to be listed.
+	Selector []string `mapstructure:"selector"`
+
+	// SortBy defines the field the list is ordered by (i.e., created, expiration, fingerprint or name).
+	SortBy string `mapstructure:"sortBy"`
+}
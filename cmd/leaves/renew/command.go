@@ -0,0 +1,233 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renew
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that renews a leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "renew FINGERPRINT",
+		Short: "Renew a leaf",
+		Long:  "Re-issue a leaf from its issuing authority with the same subject, SANs and usages, reusing the key unless --rekey is provided.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("expires", command.Flags().Lookup("expires"))
+			viper.BindPFlag("rekey", command.Flags().Lookup("rekey"))
+			viper.BindPFlag("signatureAlgorithm", command.Flags().Lookup("signatureAlgorithm"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			leaf, err := leaves.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			metadata, err := leaves.FetchMetadata(args[0])
+			if err != nil {
+				return err
+			}
+
+			if metadata.Successor != "" {
+				return fmt.Errorf("error renewing leaf [%s] already renewed by [%s]", args[0], metadata.Successor)
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			issuer, err := stores.Issuer(authorities, leaves, args[0])
+			if err != nil {
+				return errors.Wrapf(err, "error renewing leaf [%s]", args[0])
+			}
+
+			authority, err := authorities.Fetch(issuer)
+			if err != nil {
+				return errors.Wrapf(err, "error renewing leaf [%s] with issuing authority [%s]", args[0], issuer)
+			}
+
+			if authority.Key == nil {
+				return fmt.Errorf("error renewing leaf [%s] issuing authority [%s] has no key", args[0], issuer)
+			}
+
+			template, err := renewal(leaf.Certificate, options.Expires)
+			if err != nil {
+				return err
+			}
+
+			template.SignatureAlgorithm, err = signatureAlgorithm(options.SignatureAlgorithm, leaf.Certificate, authority)
+			if err != nil {
+				return err
+			}
+
+			err = stores.ApplyMetadata(authorities, issuer, &template)
+			if err != nil {
+				return err
+			}
+
+			var renewed *identities.Identity
+
+			switch {
+			case options.Rekey && leaf.Key == nil:
+				return fmt.Errorf("error rekeying leaf [%s] the key is held by the requester", args[0])
+			case options.Rekey:
+
+				key, err := keys.GenerateLike(leaf.Key.Public())
+				if err != nil {
+					return err
+				}
+
+				renewed, err = authority.Issue(template, key)
+				if err != nil {
+					return err
+				}
+
+			case leaf.Key == nil:
+
+				renewed, err = authority.Sign(template, leaf.Certificate.PublicKey)
+				if err != nil {
+					return err
+				}
+
+			default:
+
+				renewed, err = authority.Issue(template, leaf.Key)
+				if err != nil {
+					return err
+				}
+			}
+
+			fingerprint, err := stores.Store(authorities, issuer, leaves, renewed)
+			if err != nil {
+				return err
+			}
+
+			err = stores.RecordIssuer(leaves, fingerprint, issuer)
+			if err != nil {
+				return err
+			}
+
+			err = stores.Link(leaves, certificates.Fingerprint(leaf.Certificate), fingerprint)
+			if err != nil {
+				return err
+			}
+
+			if metadata.Alias != "" {
+
+				err = stores.SetAlias(leaves, certificates.Fingerprint(leaf.Certificate), "")
+				if err != nil {
+					return err
+				}
+			}
+
+			err = stores.Annotate(leaves, fingerprint, metadata.Alias, metadata.Labels)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	command.Flags().DurationP("expires", "e", 0, "expiration time for the renewal (default the lifetime of the leaf)")
+	command.Flags().Bool("rekey", false, "generate a new key of the same type and strength instead of reusing the key")
+	command.Flags().String("signatureAlgorithm", "", "signature algorithm used to sign the renewal (default the algorithm of the leaf)")
+
+	return command
+}
+
+// renewal returns a template for a certificate with the same subject, SANs and usages as the provided certificate.
+func renewal(certificate *x509.Certificate, expires time.Duration) (identities.Template, error) {
+
+	serial, err := serials.Generate()
+	if err != nil {
+		return identities.Template{}, err
+	}
+
+	if expires == 0 {
+		expires = certificate.NotAfter.Sub(certificate.NotBefore)
+	}
+
+	template := identities.Template{
+		BasicConstraintsValid: certificate.BasicConstraintsValid,
+		DNSNames:              certificate.DNSNames,
+		EmailAddresses:        certificate.EmailAddresses,
+		ExtKeyUsage:           certificate.ExtKeyUsage,
+		IPAddresses:           certificate.IPAddresses,
+		KeyUsage:              certificate.KeyUsage,
+		NotAfter:              time.Now().Add(expires),
+		NotBefore:             time.Now(),
+		PolicyIdentifiers:     certificate.PolicyIdentifiers,
+		SerialNumber:          serial,
+		Subject:               certificate.Subject,
+		URIs:                  certificate.URIs,
+		UnknownExtKeyUsage:    certificate.UnknownExtKeyUsage,
+	}
+
+	// Delegated OCSP responders must remain exempt from revocation checking (see RFC 6960 section 4.2.2.2.1).
+	for _, extension := range certificate.Extensions {
+		if extension.Id.Equal(certificates.OIDOCSPNoCheck) {
+			template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: certificates.OIDOCSPNoCheck, Value: extension.Value})
+		}
+	}
+
+	return template, nil
+}
+
+// signatureAlgorithm returns the signature algorithm with the provided name or, when no name is provided, the algorithm
+// of the certificate being renewed if the authority may still use it.
+func signatureAlgorithm(name string, certificate *x509.Certificate, authority *identities.Identity) (x509.SignatureAlgorithm, error) {
+
+	if name != "" {
+		return keys.SignatureAlgorithm(name, authority.Key.Public())
+	}
+
+	algorithm, err := keys.SignatureAlgorithm(certificate.SignatureAlgorithm.String(), authority.Key.Public())
+	if err != nil {
+		return x509.UnknownSignatureAlgorithm, errors.Wrapf(err, "error renewing with signature algorithm [%s] of [%s] (use --signatureAlgorithm)", certificate.SignatureAlgorithm, certificates.Fingerprint(certificate))
+	}
+
+	return algorithm, nil
+}
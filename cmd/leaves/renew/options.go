@@ -0,0 +1,30 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renew
+
+import "time"
+
+// Options defines the options for the renew command.
+type Options struct {
+
+	// Expires defines the lifetime of the renewal (zero selects the lifetime of the leaf being renewed).
+	Expires time.Duration `mapstructure:"expires"`
+
+	// Rekey defines whether a new key of the same type and strength replaces the key of the leaf being renewed.
+	Rekey bool `mapstructure:"rekey"`
+
+	// SignatureAlgorithm defines the algorithm used to sign the renewal (e.g., SHA384-RSA or ECDSA-SHA384).
+	SignatureAlgorithm string `mapstructure:"signatureAlgorithm"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"fmt"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that restores a deleted leaf from the trash.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "restore FINGERPRINT",
+		Short: "Restore a deleted leaf",
+		Long:  "Restore the most recently deleted leaf with the fingerprint from the trash (see acert trash list).",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := leaves.Restore(args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(fingerprint)
+
+			return nil
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"time"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/revocations"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that revokes a leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "revoke FINGERPRINT",
+		Short: "Revoke a leaf",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("reason", command.Flags().Lookup("reason"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			reason, err := revocations.Reason(options.Reason)
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			records, err := leaves.ListRecords()
+			if err != nil {
+				return err
+			}
+
+			leaf, err := stores.FindRecord(records, args[0])
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			issuer, err := stores.Issuer(authorities, leaves, leaf.Fingerprint)
+			if err != nil {
+				return errors.Wrapf(err, "error revoking leaf [%s]", leaf.Fingerprint)
+			}
+
+			err = stores.Revoke(authorities, issuer, leaf.Certificate, reason, time.Now())
+			if err != nil {
+				return errors.Wrapf(err, "error revoking leaf [%s] with issuing authority [%s]", leaf.Fingerprint, issuer)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("reason", "r", "unspecified", "the reason for the revocation [unspecified, keyCompromise, caCompromise, affiliationChanged, superseded, cessationOfOperation, certificateHold, privilegeWithdrawn, aaCompromise]")
+
+	return command
+}
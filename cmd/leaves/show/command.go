@@ -0,0 +1,77 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package show
+
+import (
+	"os"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that shows the details of a leaf.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "show FINGERPRINT",
+		Short: "Show the details of a leaf",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("output", command.Flags().Lookup("output"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			identity, err := leaves.Fetch(args[0])
+			if err != nil {
+				return err
+			}
+
+			metadata, err := leaves.FetchMetadata(certificates.Fingerprint(identity.Certificate))
+			if err != nil {
+				return err
+			}
+
+			details := certificates.Describe(identity.Certificate, identity.Authorities)
+			details.Alias = metadata.Alias
+			details.Labels = metadata.Labels
+
+			bytes, err := certificates.FormatDetails(details, options.Output)
+			if err != nil {
+				return err
+			}
+
+			os.Stdout.Write(bytes)
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("output", "o", "text", "format of the details [json, text]")
+
+	return command
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/responder"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that serves OCSP responses for the stored authorities.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve OCSP responses for the authorities",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("address", command.Flags().Lookup("address"))
+			viper.BindPFlag("nextUpdate", command.Flags().Lookup("nextUpdate"))
+			viper.BindPFlag("responders", command.Flags().Lookup("responders"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			server := responder.NewResponder(authorities, options.NextUpdate)
+
+			for _, fingerprint := range options.Responders {
+
+				leaf, err := leaves.Fetch(fingerprint)
+				if err != nil {
+					return err
+				}
+
+				err = server.Delegate(leaf)
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintf(os.Stderr, "serving OCSP responses on [%s]\n", options.Address)
+
+			return http.ListenAndServe(options.Address, server)
+		},
+	}
+
+	command.Flags().StringP("address", "a", "localhost:8080", "address on which to serve OCSP responses")
+	command.Flags().Duration("nextUpdate", time.Hour, "duration after which a newer response will be available")
+	command.Flags().StringSliceP("responders", "r", []string{}, "list of fingerprints of leaves issued with --ocspSigning that sign the responses for their authorities")
+
+	return command
+}
@@ -0,0 +1,32 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"time"
+)
+
+// Options defines the options for the serve command.
+type Options struct {
+
+	// Address defines the address on which the responder listens (e.g., localhost:8080).
+	Address string `mapstructure:"address"`
+
+	// NextUpdate defines the duration after which a newer response will be available.
+	NextUpdate time.Duration `mapstructure:"nextUpdate"`
+
+	// Responders defines the fingerprints of the leaves that sign the responses for their authorities.
+	Responders []string `mapstructure:"responders"`
+}
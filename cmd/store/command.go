@@ -0,0 +1,35 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"github.com/greymatter-io/acert/cmd/store/decrypt"
+	"github.com/greymatter-io/acert/cmd/store/encrypt"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that manages the identity stores.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "store",
+		Short: "Manage the identity stores",
+	}
+
+	command.AddCommand(decrypt.Command())
+	command.AddCommand(encrypt.Command())
+
+	return command
+}
@@ -0,0 +1,36 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trash
+
+import (
+	"github.com/greymatter-io/acert/cmd/trash/list"
+	"github.com/greymatter-io/acert/cmd/trash/purge"
+	"github.com/spf13/cobra"
+)
+
+// Command returns a command that manages deleted identities.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage deleted authorities and leaves",
+		Long:  "Manage deleted authorities and leaves.  Deleted identities are moved to the trash of their store and may be restored with acert authorities restore or acert leaves restore until they are purged.",
+	}
+
+	command.AddCommand(list.Command())
+	command.AddCommand(purge.Command())
+
+	return command
+}
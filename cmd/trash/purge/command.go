@@ -0,0 +1,115 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/internal/durations"
+	"github.com/greymatter-io/acert/internal/prompts"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/trash"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that permanently deletes the authorities and leaves in the trash.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:          "purge",
+		Short:        "Permanently delete authorities and leaves from the trash",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("olderThan", command.Flags().Lookup("older-than"))
+			viper.BindPFlag("yes", command.Flags().Lookup("yes"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			olderThan, err := durations.Parse(options.OlderThan)
+			if err != nil {
+				return err
+			}
+
+			before := time.Now().Add(-olderThan)
+
+			authorities, err := config.Authorities()
+			if err != nil {
+				return err
+			}
+
+			leaves, err := config.Leaves()
+			if err != nil {
+				return err
+			}
+
+			trashes := map[string]stores.Trash{"authority": authorities, "leaf": leaves}
+
+			items, err := trash.List(trashes, before)
+			if err != nil {
+				return err
+			}
+
+			if len(items) == 0 {
+				return nil
+			}
+
+			if !options.Yes {
+
+				bytes, err := trash.Format(items, "text")
+				if err != nil {
+					return err
+				}
+
+				os.Stderr.Write(bytes)
+
+				confirmed, err := prompts.Confirm(fmt.Sprintf("Permanently delete %d identities", len(items)))
+				if err != nil {
+					return errors.Wrap(err, "error confirming purge (use --yes to purge without confirmation)")
+				}
+
+				if !confirmed {
+					return fmt.Errorf("error purging trash not confirmed")
+				}
+			}
+
+			for _, store := range trashes {
+
+				_, err = store.Purge(before)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().String("older-than", "30d", "purge only the identities deleted longer ago than the duration (e.g., 30d, 12h or 0 for everything)")
+	command.Flags().BoolP("yes", "y", false, "purge without prompting for confirmation")
+
+	return command
+}
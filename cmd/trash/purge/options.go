@@ -0,0 +1,25 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+// Options defines the options for the purge command.
+type Options struct {
+
+	// OlderThan defines how long ago identities must have been deleted to be purged (e.g., 30d or 12h).
+	OlderThan string `mapstructure:"olderThan"`
+
+	// Yes defines whether to purge without prompting for confirmation.
+	Yes bool `mapstructure:"yes"`
+}
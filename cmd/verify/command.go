@@ -0,0 +1,189 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/config"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/internal/files"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/verifications"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Command returns a command that verifies a leaf against the stored authorities.
+func Command() *cobra.Command {
+
+	command := &cobra.Command{
+		Use:          "verify [FINGERPRINT]",
+		Short:        "Verify a leaf against the authorities",
+		Long:         "Verify a stored leaf (or a PEM encoded certificate) against the stored authorities and print the chain, explaining any failure.",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+
+			viper.BindPFlag("certificate", command.Flags().Lookup("certificate"))
+			viper.BindPFlag("dnsName", command.Flags().Lookup("dnsName"))
+			viper.BindPFlag("purpose", command.Flags().Lookup("purpose"))
+			viper.BindPFlag("roots", command.Flags().Lookup("roots"))
+			viper.BindPFlag("time", command.Flags().Lookup("time"))
+
+			var options Options
+
+			err := viper.Unmarshal(&options)
+			if err != nil {
+				return err
+			}
+
+			if (len(args) == 0) == (options.Certificate == "") {
+				return fmt.Errorf("error verifying leaf must provide one of [FINGERPRINT, --certificate]")
+			}
+
+			purpose, err := certificates.ExtKeyUsage(options.Purpose)
+			if err != nil {
+				return err
+			}
+
+			var at time.Time
+
+			if options.Time != "" {
+				at, err = time.Parse(time.RFC3339, options.Time)
+				if err != nil {
+					return fmt.Errorf("error parsing time [%s] must be in RFC 3339 format (e.g., 2006-01-02T15:04:05Z)", options.Time)
+				}
+			}
+
+			certificate, chain, err := read(args, options)
+			if err != nil {
+				return err
+			}
+
+			intermediates, roots, err := trusted(options)
+			if err != nil {
+				return err
+			}
+
+			verified, err := verifications.Verify(certificate, append(intermediates, chain...), roots, verifications.Options{
+				DNSName: options.DNSName,
+				Purpose: purpose,
+				Time:    at,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, link := range verified {
+
+				expiration := certificates.Expiration(link)
+				fingerprint := certificates.Fingerprint(link)
+				name := certificates.CommonName(link)
+
+				fmt.Printf("%s\t%s\t%v\n", fingerprint, name, expiration)
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringP("certificate", "c", "", "file containing the PEM encoded certificate optionally followed by its chain (- for stdin)")
+	command.Flags().StringP("dnsName", "d", "", "DNS name or IP address the leaf must be valid for")
+	command.Flags().StringP("purpose", "p", "any", "extended key usage the chain must permit (e.g., serverAuth, clientAuth, codeSigning)")
+	command.Flags().StringSliceP("roots", "r", []string{}, "fingerprints of the authorities to trust as roots (default the self signed authorities)")
+	command.Flags().StringP("time", "t", "", "RFC 3339 time at which the chain must be valid (default now)")
+
+	return command
+}
+
+// read returns the certificate to verify and the chain presented with it from the leaf store or a file.
+func read(args []string, options Options) (*x509.Certificate, []*x509.Certificate, error) {
+
+	if len(args) > 0 {
+
+		leaves, err := config.Leaves()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		leaf, err := leaves.Fetch(args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return leaf.Certificate, leaf.Authorities, nil
+	}
+
+	bytes, err := files.Read(options.Certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := encoding.PEMDecodeCertificates(bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(parsed) == 0 {
+		return nil, nil, fmt.Errorf("error reading certificate from [%s] no certificates found", options.Certificate)
+	}
+
+	return parsed[0], parsed[1:], nil
+}
+
+// trusted returns the certificates of the stored authorities (and their chains) and those trusted as roots without
+// reading the keys of the authorities.
+func trusted(options Options) ([]*x509.Certificate, []*x509.Certificate, error) {
+
+	authorities, err := config.Authorities()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, err := authorities.ListRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored := []*x509.Certificate{}
+	intermediates := []*x509.Certificate{}
+
+	for _, record := range records {
+		stored = append(stored, record.Certificate)
+		intermediates = append(append(intermediates, record.Certificate), record.Authorities...)
+	}
+
+	if len(options.Roots) == 0 {
+		return intermediates, verifications.Roots(stored), nil
+	}
+
+	roots := []*x509.Certificate{}
+
+	for _, fingerprint := range options.Roots {
+
+		record, err := stores.FindRecord(records, fingerprint)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		roots = append(roots, record.Certificate)
+	}
+
+	return intermediates, roots, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+// Options defines the options for the verify command.
+type Options struct {
+
+	// Certificate defines the file containing the PEM encoded certificate optionally followed by its chain.
+	Certificate string `mapstructure:"certificate"`
+
+	// DNSName defines the DNS name (or IP address) the certificate must be valid for.
+	DNSName string `mapstructure:"dnsName"`
+
+	// Purpose defines the extended key usage the chain must permit (e.g., serverAuth or clientAuth).
+	Purpose string `mapstructure:"purpose"`
+
+	// Roots defines the fingerprints of the authorities trusted as roots (empty to trust the self signed authorities).
+	Roots []string `mapstructure:"roots"`
+
+	// Time defines the RFC 3339 time at which the chain must be valid (empty for the current time).
+	Time string `mapstructure:"time"`
+}
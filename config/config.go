@@ -15,11 +15,19 @@
 package config
 
 import (
-	"os"
+	"io/ioutil"
 	"path/filepath"
 
+	"github.com/greymatter-io/acert/internal/prompts"
 	"github.com/greymatter-io/acert/stores/filesystem"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+var (
+
+	// secret caches the secret protecting the identity stores once it has been read.
+	secret []byte
 )
 
 // Authorities returns the authority identity store.
@@ -30,7 +38,7 @@ func Authorities() (*filesystem.IdentityStore, error) {
 		return nil, errors.Wrap(err, "error determining authorities directory")
 	}
 
-	return filesystem.NewIdentityStore(directory), nil
+	return filesystem.NewIdentityStore(directory).WithSecret(Secret), nil
 }
 
 // Leaves returns the leaf identity store.
@@ -41,16 +49,69 @@ func Leaves() (*filesystem.IdentityStore, error) {
 		return nil, errors.Wrap(err, "error determining leaves directory")
 	}
 
-	return filesystem.NewIdentityStore(directory), nil
+	return filesystem.NewIdentityStore(directory).WithSecret(Secret), nil
+}
+
+// Secret returns the secret protecting encrypted identity stores read from the key file (i.e., keyFile), the
+// passphrase (i.e., passphrase) or a prompt.
+func Secret() ([]byte, error) {
+	return readSecret(prompts.Password)
+}
+
+// NewSecret returns the secret protecting encrypted identity stores as Secret does but prompts for the passphrase
+// twice to guard against typographical errors.
+func NewSecret() ([]byte, error) {
+	return readSecret(prompts.NewPassword)
+}
+
+// readSecret returns the secret protecting encrypted identity stores using a function to prompt for a passphrase.
+func readSecret(prompt func(string) (string, error)) ([]byte, error) {
+
+	if secret != nil {
+		return secret, nil
+	}
+
+	var value []byte
+
+	if file := viper.GetString("keyFile"); file != "" {
+
+		bytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading key file [%s]", file)
+		}
+
+		value = bytes
+
+	} else if passphrase := viper.GetString("passphrase"); passphrase != "" {
+
+		value = []byte(passphrase)
+
+	} else {
+
+		passphrase, err := prompt("Store passphrase")
+		if err != nil {
+			return nil, err
+		}
+
+		value = []byte(passphrase)
+	}
+
+	if len(value) == 0 {
+		return nil, errors.New("error reading secret the passphrase or key file is empty")
+	}
+
+	secret = value
+
+	return secret, nil
 }
 
-// relative returns the absolute path to a relative path in the configuration directory.
+// relative returns the absolute path to a relative path in the home of the active context (see Home).
 func relative(path string) (string, error) {
 
-	home, err := os.UserHomeDir()
+	home, err := Home()
 	if err != nil {
-		return "", errors.Wrap(err, "error determining user home directory")
+		return "", err
 	}
 
-	return filepath.Join(home, ".acert", path), nil
+	return filepath.Join(home, path), nil
 }
@@ -0,0 +1,232 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+
+	// contextsFile defines the name of the file in the default home recording the contexts.
+	contextsFile = "config.yaml"
+
+	// defaultHome defines the name of the directory in the user home directory used when no home is configured.
+	defaultHome = ".acert"
+)
+
+// contextNames defines the characters allowed in the names of contexts.
+var contextNames = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Context defines a named home containing the authority and leaf stores.
+type Context struct {
+
+	// Home defines the absolute path of the directory containing the stores of the context.
+	Home string `yaml:"home"`
+}
+
+// Contexts defines the contexts and the context that is currently in use.
+type Contexts struct {
+
+	// Contexts defines the contexts keyed by name.
+	Contexts map[string]*Context `yaml:"contexts,omitempty"`
+
+	// Current defines the name of the context in use when no home or context is provided.
+	Current string `yaml:"currentContext,omitempty"`
+}
+
+// Add adds a context with a name and a home (made absolute) returning an error if the context already exists.
+func (c *Contexts) Add(name string, home string) error {
+
+	if !contextNames.MatchString(name) {
+		return fmt.Errorf("error parsing context name [%s] must start with a letter or digit followed by letters, digits, '.', '_' or '-'", name)
+	}
+
+	if _, found := c.Contexts[name]; found {
+		return fmt.Errorf("error creating context [%s] already exists", name)
+	}
+
+	if home == "" {
+		return fmt.Errorf("error creating context [%s] no home provided", name)
+	}
+
+	absolute, err := filepath.Abs(home)
+	if err != nil {
+		return errors.Wrapf(err, "error creating context [%s]", name)
+	}
+
+	if c.Contexts == nil {
+		c.Contexts = map[string]*Context{}
+	}
+
+	c.Contexts[name] = &Context{Home: absolute}
+
+	return nil
+}
+
+// Names returns the sorted names of the contexts.
+func (c *Contexts) Names() []string {
+
+	names := []string{}
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Remove removes the context with a name (no context is current after removing the current context).
+func (c *Contexts) Remove(name string) error {
+
+	if _, found := c.Contexts[name]; !found {
+		return fmt.Errorf("error deleting context [%s] not found", name)
+	}
+
+	delete(c.Contexts, name)
+
+	if c.Current == name {
+		c.Current = ""
+	}
+
+	return nil
+}
+
+// Use makes the context with a name current.
+func (c *Contexts) Use(name string) error {
+
+	if _, found := c.Contexts[name]; !found {
+		return fmt.Errorf("error using context [%s] not found", name)
+	}
+
+	c.Current = name
+
+	return nil
+}
+
+// ReadContexts reads the contexts from the contexts file returning no contexts if the file does not exist.
+func ReadContexts() (*Contexts, error) {
+
+	path, err := contextsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Contexts{}, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "error reading contexts from [%s]", path)
+	}
+
+	var contexts Contexts
+
+	err = yaml.Unmarshal(bytes, &contexts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing contexts from [%s]", path)
+	}
+
+	return &contexts, nil
+}
+
+// WriteContexts writes the contexts to the contexts file.
+func WriteContexts(contexts *Contexts) error {
+
+	path, err := contextsPath()
+	if err != nil {
+		return err
+	}
+
+	bytes, err := yaml.Marshal(contexts)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding contexts for [%s]", path)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "error creating parent directory [%s]", filepath.Dir(path))
+	}
+
+	err = ioutil.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error writing contexts to [%s]", path)
+	}
+
+	return nil
+}
+
+// Home returns the directory containing the stores.  The home (i.e., home or ACERT_HOME) takes precedence over the
+// context (i.e., context or ACERT_CONTEXT) which takes precedence over the current context.  The default home (i.e.,
+// ~/.acert) is used if none of them are defined.
+func Home() (string, error) {
+
+	if home := viper.GetString("home"); home != "" {
+		return filepath.Abs(home)
+	}
+
+	contexts, err := ReadContexts()
+	if err != nil {
+		return "", err
+	}
+
+	name := viper.GetString("context")
+	if name == "" {
+		name = contexts.Current
+	}
+
+	if name == "" {
+		return userHome()
+	}
+
+	context, found := contexts.Contexts[name]
+	if !found {
+		return "", fmt.Errorf("error resolving context [%s] not found", name)
+	}
+
+	return context.Home, nil
+}
+
+// contextsPath returns the path of the contexts file.  The file is kept in the default home so that contexts are found
+// regardless of the home in use.
+func contextsPath() (string, error) {
+
+	home, err := userHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, contextsFile), nil
+}
+
+// userHome returns the default home in the user home directory.
+func userHome() (string, error) {
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error determining user home directory")
+	}
+
+	return filepath.Join(home, defaultHome), nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/viper"
+)
+
+func TestContexts(t *testing.T) {
+
+	Convey("Contexts", t, func() {
+
+		contexts := &Contexts{}
+
+		So(contexts.Add("dev", "dev"), ShouldBeNil)
+		So(contexts.Add("prod", "/srv/acert"), ShouldBeNil)
+
+		Convey(".Add", func() {
+
+			Convey("it makes the home absolute", func() {
+				absolute, err := filepath.Abs("dev")
+				So(err, ShouldBeNil)
+				So(contexts.Contexts["dev"].Home, ShouldEqual, absolute)
+			})
+
+			Convey("when the context exists it returns an error", func() {
+				So(contexts.Add("dev", "other"), ShouldNotBeNil)
+			})
+
+			Convey("when the name is invalid it returns an error", func() {
+				So(contexts.Add("-dev", "other"), ShouldNotBeNil)
+			})
+
+			Convey("when no home is provided it returns an error", func() {
+				So(contexts.Add("test", ""), ShouldNotBeNil)
+			})
+		})
+
+		Convey(".Names", func() {
+
+			Convey("it returns the sorted names", func() {
+				So(contexts.Names(), ShouldResemble, []string{"dev", "prod"})
+			})
+		})
+
+		Convey(".Use", func() {
+
+			Convey("it makes the context current", func() {
+				So(contexts.Use("prod"), ShouldBeNil)
+				So(contexts.Current, ShouldEqual, "prod")
+			})
+
+			Convey("when the context does not exist it returns an error", func() {
+				So(contexts.Use("test"), ShouldNotBeNil)
+			})
+		})
+
+		Convey(".Remove", func() {
+
+			Convey("when the context is current no context is current", func() {
+				So(contexts.Use("prod"), ShouldBeNil)
+				So(contexts.Remove("prod"), ShouldBeNil)
+				So(contexts.Current, ShouldBeEmpty)
+				So(contexts.Names(), ShouldResemble, []string{"dev"})
+			})
+
+			Convey("when the context does not exist it returns an error", func() {
+				So(contexts.Remove("test"), ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Home", t, func() {
+
+		user, err := ioutil.TempDir("", "home")
+		So(err, ShouldBeNil)
+
+		previous := os.Getenv("HOME")
+		So(os.Setenv("HOME", user), ShouldBeNil)
+
+		viper.Reset()
+		viper.BindEnv("context", "ACERT_CONTEXT")
+
+		Reset(func() {
+			os.Setenv("HOME", previous)
+			os.Unsetenv("ACERT_CONTEXT")
+			viper.Reset()
+		})
+
+		contexts := &Contexts{}
+
+		So(contexts.Add("dev", "/srv/dev"), ShouldBeNil)
+		So(contexts.Add("prod", "/srv/prod"), ShouldBeNil)
+		So(contexts.Use("prod"), ShouldBeNil)
+
+		Convey("when there are no contexts it returns the default home", func() {
+
+			home, err := Home()
+			So(err, ShouldBeNil)
+			So(home, ShouldEqual, filepath.Join(user, ".acert"))
+		})
+
+		Convey("when a context is current it returns the home of the current context", func() {
+
+			So(WriteContexts(contexts), ShouldBeNil)
+
+			home, err := Home()
+			So(err, ShouldBeNil)
+			So(home, ShouldEqual, "/srv/prod")
+		})
+
+		Convey("when a context is provided by ACERT_CONTEXT it takes precedence over the current context", func() {
+
+			So(WriteContexts(contexts), ShouldBeNil)
+			So(os.Setenv("ACERT_CONTEXT", "dev"), ShouldBeNil)
+
+			home, err := Home()
+			So(err, ShouldBeNil)
+			So(home, ShouldEqual, "/srv/dev")
+		})
+
+		Convey("when a context is provided by --context it takes precedence over ACERT_CONTEXT", func() {
+
+			So(WriteContexts(contexts), ShouldBeNil)
+			So(os.Setenv("ACERT_CONTEXT", "prod"), ShouldBeNil)
+
+			viper.Set("context", "dev")
+
+			home, err := Home()
+			So(err, ShouldBeNil)
+			So(home, ShouldEqual, "/srv/dev")
+		})
+
+		Convey("when a home is provided it takes precedence over the context", func() {
+
+			So(WriteContexts(contexts), ShouldBeNil)
+
+			viper.Set("context", "dev")
+			viper.Set("home", "/srv/acert")
+
+			home, err := Home()
+			So(err, ShouldBeNil)
+			So(home, ShouldEqual, "/srv/acert")
+		})
+
+		Convey("when the context is unknown it returns an error", func() {
+
+			So(WriteContexts(contexts), ShouldBeNil)
+
+			viper.Set("context", "test")
+
+			_, err := Home()
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "error resolving context [test] not found")
+		})
+	})
+}
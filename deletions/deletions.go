@@ -0,0 +1,193 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletions
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/revocations"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// Delete identifies identities that are deleted.
+	Delete = "delete"
+
+	// Orphan identifies identities that are kept without their issuing authority.
+	Orphan = "orphan"
+
+	// Revoke identifies identities that are revoked by their issuing authority before the authority being deleted is
+	// deleted.
+	Revoke = "revoke"
+)
+
+// Action defines what happens to an identity when an authority is deleted.
+type Action struct {
+
+	// Certificate defines the certificate of the identity.
+	Certificate *x509.Certificate
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string
+
+	// Issuer defines the fingerprint of the authority that issued the identity (empty for the authority being deleted).
+	Issuer string
+
+	// Name defines the action taken (i.e., delete, orphan or revoke).
+	Name string
+
+	// Type defines the type of the identity (i.e., authority or leaf).
+	Type string
+}
+
+// Plan returns the actions taken on the dependents of an authority (followed by the authority itself) when the
+// authority is deleted (see stores.Dependents).  Dependents are deleted or, if live, revoked as defined by the cascade
+// (i.e., delete, revoke or empty) and otherwise orphaned.
+func Plan(fingerprint string, authority *x509.Certificate, dependents []*stores.Dependent, cascade string) []*Action {
+
+	actions := []*Action{}
+
+	for _, dependent := range dependents {
+
+		name := Orphan
+
+		switch {
+		case cascade == Delete:
+			name = Delete
+		case cascade == Revoke && dependent.Live:
+			name = Revoke
+		}
+
+		actions = append(actions, &Action{
+			Certificate: dependent.Certificate,
+			Fingerprint: dependent.Fingerprint,
+			Issuer:      dependent.Issuer,
+			Name:        name,
+			Type:        dependent.Type,
+		})
+	}
+
+	return append(actions, &Action{Certificate: authority, Fingerprint: fingerprint, Name: Delete, Type: "authority"})
+}
+
+// Apply takes the actions planned for deleting the authority with the provided fingerprint on the identities in the
+// authority and leaf stores at the provided time.  Revoked identities are revoked for cessation of operation and
+// deleted identities are moved to the trash (see stores.Remove) with the provided reason for the authority.  When
+// identities issued by the authority itself are revoked a final certificate revocation list of the authority, valid
+// until the authority expires, is signed before the authority is deleted and returned (otherwise nil) so that the
+// revocations can still be served.
+func Apply(authorities stores.IdentityStore, leaves stores.IdentityStore, fingerprint string, actions []*Action, reason string, now time.Time) (*x509.RevocationList, error) {
+
+	code, err := revocations.Reason("cessationOfOperation")
+	if err != nil {
+		return nil, err
+	}
+
+	var list *x509.RevocationList
+
+	revoked := false
+
+	for _, action := range actions {
+
+		store := authorities
+		if action.Type == "leaf" {
+			store = leaves
+		}
+
+		switch {
+		case action.Name == Revoke:
+			err = stores.Revoke(authorities, action.Issuer, action.Certificate, code, now)
+			revoked = revoked || action.Issuer == fingerprint
+		case action.Name == Delete && action.Fingerprint == fingerprint:
+			if revoked {
+				list, err = finalRevocationList(authorities, fingerprint, now)
+			}
+			if err == nil {
+				err = stores.Remove(store, action.Fingerprint, reason)
+			}
+		case action.Name == Delete:
+			err = stores.Remove(store, action.Fingerprint, fmt.Sprintf("deleted with authority [%s]", fingerprint))
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "error applying action [%s] to [%s]", action.Name, action.Fingerprint)
+		}
+	}
+
+	return list, nil
+}
+
+// finalRevocationList returns the last certificate revocation list of the authority with the provided fingerprint
+// which is valid until the authority expires.
+func finalRevocationList(authorities stores.IdentityStore, fingerprint string, now time.Time) (*x509.RevocationList, error) {
+
+	authority, err := authorities.Fetch(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, err := keys.SignatureAlgorithm("", authority.Key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := revocations.Entries(metadata.Revocations)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CRL number must increase monotonically for each list issued by the authority (see RFC 5280).
+	metadata.CRLNumber++
+
+	list, err := authority.RevocationList(entries, big.NewInt(metadata.CRLNumber), now, authority.Certificate.NotAfter, algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorities.UpdateMetadata(fingerprint, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Write writes the actions as a table.
+func Write(destination io.Writer, actions []*Action) {
+
+	writer := tabwriter.NewWriter(destination, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "ACTION\tFINGERPRINT\tTYPE\tNAME\tEXPIRATION")
+
+	for _, action := range actions {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", action.Name, action.Fingerprint, action.Type, certificates.CommonName(action.Certificate), action.Certificate.NotAfter.Format(time.RFC3339))
+	}
+
+	writer.Flush()
+}
@@ -0,0 +1,180 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeletions(t *testing.T) {
+
+	Convey("Plan", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		intermediate := tests.MustGenerateIntermediate(t, authority, keys.ECDSA, "P256", time.Now())
+		leaf := tests.MustGenerateLeaf(t, intermediate, keys.ECDSA, "P256")
+
+		dependents := []*stores.Dependent{
+			{Certificate: intermediate.Certificate, Fingerprint: "intermediate", Issuer: "root", Live: true, Type: "authority"},
+			{Certificate: leaf.Certificate, Fingerprint: "leaf", Issuer: "intermediate", Live: false, Type: "leaf"},
+		}
+
+		Convey("when there is no cascade it orphans the dependents before deleting the authority", func() {
+
+			actions := Plan("root", authority.Certificate, dependents, "")
+
+			So(actions, ShouldHaveLength, 3)
+			So(actions[0].Name, ShouldEqual, Orphan)
+			So(actions[1].Name, ShouldEqual, Orphan)
+			So(actions[2].Fingerprint, ShouldEqual, "root")
+			So(actions[2].Name, ShouldEqual, Delete)
+			So(actions[2].Type, ShouldEqual, "authority")
+		})
+
+		Convey("when the cascade is delete it deletes the dependents", func() {
+
+			actions := Plan("root", authority.Certificate, dependents, Delete)
+
+			So(actions[0].Name, ShouldEqual, Delete)
+			So(actions[0].Type, ShouldEqual, "authority")
+			So(actions[1].Name, ShouldEqual, Delete)
+			So(actions[1].Type, ShouldEqual, "leaf")
+		})
+
+		Convey("when the cascade is revoke it revokes the live dependents and orphans the others", func() {
+
+			actions := Plan("root", authority.Certificate, dependents, Revoke)
+
+			So(actions[0].Name, ShouldEqual, Revoke)
+			So(actions[0].Issuer, ShouldEqual, "root")
+			So(actions[1].Name, ShouldEqual, Orphan)
+		})
+	})
+
+	Convey("Write", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+		var buffer bytes.Buffer
+
+		Write(&buffer, Plan("root", authority.Certificate, []*stores.Dependent{
+			{Certificate: leaf.Certificate, Fingerprint: "leaf", Issuer: "root", Live: true, Type: "leaf"},
+		}, Delete))
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+		Convey("it writes a header followed by a row for each action", func() {
+			So(lines, ShouldHaveLength, 3)
+			So(strings.Fields(lines[0]), ShouldResemble, []string{"ACTION", "FINGERPRINT", "TYPE", "NAME", "EXPIRATION"})
+			So(strings.Fields(lines[1])[:3], ShouldResemble, []string{"delete", "leaf", "leaf"})
+			So(strings.Fields(lines[2])[:3], ShouldResemble, []string{"delete", "root", "authority"})
+			So(lines[1], ShouldContainSubstring, leaf.Certificate.NotAfter.Format(time.RFC3339))
+		})
+	})
+
+	Convey("Apply", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		intermediate := tests.MustGenerateIntermediate(t, authority, keys.ECDSA, "P256", time.Now())
+		leaf := tests.MustGenerateLeaf(t, intermediate, keys.ECDSA, "P256")
+
+		root, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		subordinate, err := authorities.Upsert(intermediate)
+		So(err, ShouldBeNil)
+
+		issued, err := leaves.Upsert(leaf)
+		So(err, ShouldBeNil)
+
+		dependents, err := stores.Dependents(authorities, leaves, root, time.Now())
+		So(err, ShouldBeNil)
+
+		Convey("when the cascade is delete", func() {
+
+			list, err := Apply(authorities, leaves, root, Plan(root, authority.Certificate, dependents, Delete), "deleted by test", time.Now())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a nil revocation list", func() {
+				So(list, ShouldBeNil)
+			})
+
+			Convey("it deletes the authority and everything it issued", func() {
+
+				_, err := authorities.Fetch(root)
+				So(err, ShouldNotBeNil)
+
+				_, err = authorities.Fetch(subordinate)
+				So(err, ShouldNotBeNil)
+
+				_, err = leaves.Fetch(issued)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the cascade is revoke", func() {
+
+			list, err := Apply(authorities, leaves, root, Plan(root, authority.Certificate, dependents, Revoke), "deleted by test", time.Now())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the final revocation list of the authority valid until the authority expires", func() {
+
+				So(list, ShouldNotBeNil)
+				So(list.CheckSignatureFrom(authority.Certificate), ShouldBeNil)
+				So(list.NextUpdate.Equal(authority.Certificate.NotAfter), ShouldBeTrue)
+				So(list.RevokedCertificateEntries, ShouldHaveLength, 1)
+				So(list.RevokedCertificateEntries[0].SerialNumber.Cmp(intermediate.Certificate.SerialNumber), ShouldEqual, 0)
+			})
+
+			Convey("it revokes the live dependents by their issuers and keeps them", func() {
+
+				metadata, err := authorities.FetchMetadata(subordinate)
+				So(err, ShouldBeNil)
+				So(metadata.Revocations, ShouldContainKey, serials.Format(leaf.Certificate.SerialNumber))
+
+				_, err = authorities.Fetch(subordinate)
+				So(err, ShouldBeNil)
+
+				_, err = leaves.Fetch(issued)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it deletes the authority", func() {
+				_, err := authorities.Fetch(root)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -15,11 +15,13 @@
 package encoding
 
 import (
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 	"encoding/base64"
 	"net/url"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // ConfigEncodeCertificate returns a X.509 certificate encoded for use in an IdentityConfig.
@@ -38,7 +40,85 @@ func ConfigEncodeCertificates(certificates []*x509.Certificate) string {
 	return url.PathEscape(base64.StdEncoding.EncodeToString([]byte(strings.Join(pems, "\n"))))
 }
 
-// ConfigEncodeKey returns an RSA key encoded for use in an IdentityConfig.
-func ConfigEncodeKey(key *rsa.PrivateKey) string {
-	return url.PathEscape(base64.StdEncoding.EncodeToString([]byte(PEMEncodeKey(key))))
+// ConfigEncodeCertificateRequest returns a PKCS #10 certificate request encoded for use in an IdentityConfig.
+func ConfigEncodeCertificateRequest(request *x509.CertificateRequest) string {
+	return url.PathEscape(base64.StdEncoding.EncodeToString([]byte(PEMEncodeCertificateRequest(request))))
+}
+
+// ConfigEncodeKey returns a private key encoded for use in an IdentityConfig.
+func ConfigEncodeKey(key crypto.Signer) (string, error) {
+
+	encoded, err := PEMEncodeKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return url.PathEscape(base64.StdEncoding.EncodeToString([]byte(encoded))), nil
+}
+
+// ConfigDecodeCertificate returns the single X.509 certificate encoded for use in an IdentityConfig.
+func ConfigDecodeCertificate(value string) (*x509.Certificate, error) {
+
+	certificates, err := ConfigDecodeCertificates(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(certificates) {
+	case 0:
+		return nil, errors.New("no certificates defined")
+	case 1:
+		return certificates[0], nil
+	default:
+		return nil, errors.New("multiple certificates defined")
+	}
+}
+
+// ConfigDecodeCertificates returns the X.509 certificates encoded for use in an IdentityConfig.
+func ConfigDecodeCertificates(value string) ([]*x509.Certificate, error) {
+
+	bytes, err := configDecode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return PEMDecodeCertificates(bytes)
+}
+
+// ConfigDecodeCertificateRequest returns the PKCS #10 certificate request encoded for use in an IdentityConfig.
+func ConfigDecodeCertificateRequest(value string) (*x509.CertificateRequest, error) {
+
+	bytes, err := configDecode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return PEMDecodeCertificateRequest(bytes)
+}
+
+// ConfigDecodeKey returns the private key encoded for use in an IdentityConfig.
+func ConfigDecodeKey(value string) (crypto.Signer, error) {
+
+	bytes, err := configDecode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return PEMDecodeKey(bytes)
+}
+
+// configDecode returns the bytes of a value encoded for use in an IdentityConfig.
+func configDecode(value string) ([]byte, error) {
+
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unescaping value [%s]", value)
+	}
+
+	bytes, err := base64.StdEncoding.DecodeString(strings.TrimLeft(unescaped, "/"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding value [%s]", unescaped)
+	}
+
+	return bytes, nil
 }
@@ -0,0 +1,132 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// DER identifies the binary ASN.1 DER format (PKCS #8 for keys).
+	DER = "der"
+
+	// PEM identifies the PEM format (PKCS #8 for keys).
+	PEM = "pem"
+
+	// PKCS12 identifies the binary PKCS #12 key store and trust store format.
+	PKCS12 = "p12"
+
+	// PKCS1 identifies the PEM encoded PKCS #1 format for RSA keys.
+	PKCS1 = "pkcs1"
+
+	// PKCS8 identifies the PEM encoded PKCS #8 format for keys.
+	PKCS8 = "pkcs8"
+)
+
+// Binary returns true if a format produces binary rather than textual output.
+func Binary(format string) bool {
+	return strings.EqualFold(format, DER) || strings.EqualFold(format, PKCS12)
+}
+
+// EncodeCertificate returns an X.509 certificate encoded in the provided format [der, pem].
+func EncodeCertificate(certificate *x509.Certificate, format string) ([]byte, error) {
+	return EncodeCertificates([]*x509.Certificate{certificate}, format)
+}
+
+// EncodeCertificates returns X.509 certificates encoded in the provided format [der, pem].  Note that the DER format
+// can only represent a single certificate.
+func EncodeCertificates(certificates []*x509.Certificate, format string) ([]byte, error) {
+
+	switch strings.ToLower(format) {
+	case DER:
+
+		if len(certificates) != 1 {
+			return nil, fmt.Errorf("error encoding [%d] certificates format [%s] supports exactly one certificate", len(certificates), format)
+		}
+
+		return certificates[0].Raw, nil
+
+	case PEM:
+		return []byte(strings.Join(PEMEncodeCertificates(certificates), "")), nil
+
+	default:
+		return nil, fmt.Errorf("error parsing format [%s] must be one of [der, p12, pem]", format)
+	}
+}
+
+// EncodeKey returns a private key encoded in the provided format [der, pem, pkcs1, pkcs8].
+func EncodeKey(key crypto.Signer, format string) ([]byte, error) {
+
+	switch strings.ToLower(format) {
+	case DER:
+
+		bytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling key of type [%T]", key)
+		}
+
+		return bytes, nil
+
+	case PEM, PKCS8:
+
+		encoded, err := PEMEncodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(encoded), nil
+
+	case PKCS1:
+
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("error encoding key of type [%T] format [%s] supports only rsa keys", key, format)
+		}
+
+		var buffer bytes.Buffer
+
+		err := pem.Encode(&buffer, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding pkcs1 key")
+		}
+
+		return buffer.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("error parsing format [%s] must be one of [der, p12, pem, pkcs1, pkcs8]", format)
+	}
+}
+
+// EncodeRevocationList returns an X.509 certificate revocation list encoded in the provided format [der, pem].
+func EncodeRevocationList(list *x509.RevocationList, format string) ([]byte, error) {
+
+	switch strings.ToLower(format) {
+	case DER:
+		return list.Raw, nil
+	case PEM:
+		return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: list.Raw}), nil
+	default:
+		return nil, fmt.Errorf("error parsing format [%s] must be one of [der, pem]", format)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormats(t *testing.T) {
+
+	Convey("EncodeCertificates", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+		Convey("when the format is der", func() {
+
+			Convey("with a single certificate", func() {
+
+				bytes, err := EncodeCertificates([]*x509.Certificate{leaf.Certificate}, "DER")
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the raw certificate", func() {
+					So(bytes, ShouldResemble, leaf.Certificate.Raw)
+				})
+			})
+
+			Convey("with multiple certificates", func() {
+
+				_, err := EncodeCertificates([]*x509.Certificate{leaf.Certificate, authority.Certificate}, "der")
+
+				Convey("it returns a non-nil error", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("when the format is unknown", func() {
+
+			_, err := EncodeCertificates([]*x509.Certificate{leaf.Certificate}, "pkcs1")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("EncodeKey", t, func() {
+
+		Convey("when the format is der", func() {
+
+			authority := tests.MustGenerateAuthority(t, keys.Ed25519, "")
+			bytes, err := EncodeKey(authority.Key, "der")
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a pkcs8 key", func() {
+				key, err := x509.ParsePKCS8PrivateKey(bytes)
+				So(err, ShouldBeNil)
+				So(key, ShouldResemble, authority.Key)
+			})
+		})
+
+		Convey("when the format is pkcs1", func() {
+
+			Convey("with an rsa key", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.RSA, "")
+				bytes, err := EncodeKey(authority.Key, "pkcs1")
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns a pem encoded pkcs1 key", func() {
+					block, _ := pem.Decode(bytes)
+					So(block.Type, ShouldEqual, "RSA PRIVATE KEY")
+				})
+			})
+
+			Convey("with an ecdsa key", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+				_, err := EncodeKey(authority.Key, "pkcs1")
+
+				Convey("it returns a non-nil error", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+		})
+
+		Convey("when the format is pkcs8", func() {
+
+			authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P384")
+			bytes, err := EncodeKey(authority.Key, "pkcs8")
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a key that can be decoded", func() {
+				key, err := PEMDecodeKey(bytes)
+				So(err, ShouldBeNil)
+				So(key, ShouldResemble, authority.Key)
+			})
+		})
+
+		Convey("when the format is unknown", func() {
+
+			authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+			_, err := EncodeKey(authority.Key, "jks")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -15,9 +15,13 @@
 package encoding
 
 import (
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // PEMEncodeCertificate returns the PEM encoded string for an X.509 certificate.
@@ -36,7 +40,124 @@ func PEMEncodeCertificates(certificates []*x509.Certificate) []string {
 	return pems
 }
 
-// PEMEncodeKey returns the PEM encoded string for an RSA key.
-func PEMEncodeKey(key *rsa.PrivateKey) string {
-	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+// PEMEncodeCertificateRequest returns the PEM encoded string for a PKCS #10 certificate request.
+func PEMEncodeCertificateRequest(request *x509.CertificateRequest) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request.Raw}))
+}
+
+// PEMEncodeKey returns the PKCS #8 PEM encoded string for an ECDSA, Ed25519 or RSA key.
+func PEMEncodeKey(key crypto.Signer) (string, error) {
+
+	bytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", errors.Wrapf(err, "error marshalling key of type [%T]", key)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: bytes})), nil
+}
+
+// PEMDecodeCertificates returns the X.509 certificates from PEM encoded bytes.
+func PEMDecodeCertificates(bytes []byte) ([]*x509.Certificate, error) {
+
+	var certificates []*x509.Certificate
+
+	block, rest := pem.Decode(bytes)
+	for block != nil {
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing certificate")
+		}
+
+		certificates = append(certificates, certificate)
+
+		block, rest = pem.Decode(rest)
+	}
+
+	return certificates, nil
+}
+
+// PEMDecodeCertificateRequest returns the single PKCS #10 certificate request from PEM encoded bytes.
+func PEMDecodeCertificateRequest(bytes []byte) (*x509.CertificateRequest, error) {
+
+	block, rest := pem.Decode(bytes)
+	if block == nil {
+		return nil, errors.New("no certificate requests defined")
+	}
+
+	if next, _ := pem.Decode(rest); next != nil {
+		return nil, errors.New("multiple certificate requests defined")
+	}
+
+	if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("error parsing certificate request of unsupported type [%s]", block.Type)
+	}
+
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing certificate request")
+	}
+
+	return request, nil
+}
+
+// PEMDecodeKey returns the single key from PEM encoded bytes in PKCS #8, PKCS #1 (RSA) or SEC 1 (ECDSA) form.  Blocks
+// that are not private keys (e.g., the EC PARAMETERS written by openssl ecparam -genkey) are skipped.
+func PEMDecodeKey(bytes []byte) (crypto.Signer, error) {
+
+	var block *pem.Block
+
+	for next, rest := pem.Decode(bytes); next != nil; next, rest = pem.Decode(rest) {
+
+		if !strings.HasSuffix(next.Type, "PRIVATE KEY") {
+			continue
+		}
+
+		if block != nil {
+			return nil, errors.New("multiple keys defined")
+		}
+
+		block = next
+	}
+
+	if block == nil {
+		return nil, errors.New("no keys defined")
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing ecdsa key")
+		}
+
+		return key, nil
+
+	case "PRIVATE KEY":
+
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing pkcs8 key")
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("error parsing key of unsupported type [%T]", key)
+		}
+
+		return signer, nil
+
+	case "RSA PRIVATE KEY":
+
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing rsa key")
+		}
+
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("error parsing key of unsupported type [%s]", block.Type)
+	}
 }
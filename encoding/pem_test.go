@@ -0,0 +1,98 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPEM(t *testing.T) {
+
+	Convey("PEMDecodeKey", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		key, err := EncodeKey(authority.Key, "pkcs8")
+		So(err, ShouldBeNil)
+
+		certificate := []byte(PEMEncodeCertificate(authority.Certificate))
+
+		Convey("when the key is preceded by ec parameters (e.g., openssl ecparam -genkey)", func() {
+
+			bytes, err := ioutil.ReadFile("./testdata/ecparam.pem")
+			So(err, ShouldBeNil)
+
+			decoded, err := PEMDecodeKey(bytes)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the ecdsa key", func() {
+				So(decoded, ShouldHaveSameTypeAs, &ecdsa.PrivateKey{})
+			})
+		})
+
+		Convey("when the key is followed by a certificate", func() {
+
+			decoded, err := PEMDecodeKey(append(key, certificate...))
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the key", func() {
+				So(decoded, ShouldResemble, authority.Key)
+			})
+		})
+
+		Convey("when the key is preceded by a certificate", func() {
+
+			decoded, err := PEMDecodeKey(append(certificate, key...))
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the key", func() {
+				So(decoded, ShouldResemble, authority.Key)
+			})
+		})
+
+		Convey("when there are multiple keys", func() {
+
+			_, err := PEMDecodeKey(append(append([]byte{}, key...), key...))
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when there are no keys", func() {
+
+			_, err := PEMDecodeKey(certificate)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
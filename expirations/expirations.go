@@ -0,0 +1,176 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expirations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// Expired identifies identities that have expired.
+	Expired = "expired"
+
+	// Expiring identifies identities that expire within the window being checked.
+	Expiring = "expiring"
+
+	// IssuerExpiring identifies identities that outlive an authority in their chain that expires within the window
+	// being checked (i.e., the identity stops validating before it expires).
+	IssuerExpiring = "issuerExpiring"
+)
+
+// Finding defines an identity that needs attention because it or an authority in its chain expires.
+type Finding struct {
+
+	// CommonName defines the common name of the identity.
+	CommonName string `json:"commonName"`
+
+	// Expiration defines when the identity expires.
+	Expiration time.Time `json:"expiration"`
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string `json:"fingerprint"`
+
+	// Issuer defines the fingerprint of the authority in the chain that expires first for IssuerExpiring findings.
+	Issuer string `json:"issuer,omitempty"`
+
+	// IssuerExpiration defines when the authority in the chain that expires first expires for IssuerExpiring findings.
+	IssuerExpiration *time.Time `json:"issuerExpiration,omitempty"`
+
+	// Status defines why the identity needs attention (i.e., expired, expiring or issuerExpiring).
+	Status string `json:"status"`
+
+	// Type defines the type of the identity (e.g., authority or leaf).
+	Type string `json:"type"`
+}
+
+// Check returns the findings for the identities in a store of the provided type (e.g., authority or leaf) that expire,
+// or have an authority in their chain that expires, before now plus the provided window.  Identities that have been
+// renewed are skipped and the findings are ordered by expiration.  Only the records of the store are read (i.e., the
+// keys of an encrypted store are not decrypted).
+func Check(store stores.IdentityStore, identityType string, now time.Time, within time.Duration) ([]*Finding, error) {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := now.Add(within)
+	findings := []*Finding{}
+
+	for _, record := range records {
+
+		if record.Metadata.Successor != "" {
+			continue
+		}
+
+		finding := &Finding{
+			CommonName:  certificates.CommonName(record.Certificate),
+			Expiration:  record.Certificate.NotAfter,
+			Fingerprint: record.Fingerprint,
+			Type:        identityType,
+		}
+
+		switch {
+		case record.Certificate.NotAfter.Before(now):
+			finding.Status = Expired
+		case record.Certificate.NotAfter.Before(deadline):
+			finding.Status = Expiring
+		default:
+
+			for _, authority := range record.Authorities {
+
+				if !authority.NotAfter.Before(record.Certificate.NotAfter) || !authority.NotAfter.Before(deadline) {
+					continue
+				}
+
+				if finding.IssuerExpiration == nil || authority.NotAfter.Before(*finding.IssuerExpiration) {
+					expiration := authority.NotAfter
+					finding.Issuer = certificates.Fingerprint(authority)
+					finding.IssuerExpiration = &expiration
+					finding.Status = IssuerExpiring
+				}
+			}
+		}
+
+		if finding.Status != "" {
+			findings = append(findings, finding)
+		}
+	}
+
+	Sort(findings)
+
+	return findings, nil
+}
+
+// Sort orders findings by expiration and then by fingerprint.
+func Sort(findings []*Finding) {
+
+	sort.Slice(findings, func(i, j int) bool {
+
+		if !findings[i].Expiration.Equal(findings[j].Expiration) {
+			return findings[i].Expiration.Before(findings[j].Expiration)
+		}
+
+		return findings[i].Fingerprint < findings[j].Fingerprint
+	})
+}
+
+// Format returns the findings in the provided output format (i.e., json or text).
+func Format(findings []*Finding, output string) ([]byte, error) {
+
+	switch output {
+	case "json":
+
+		bytes, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding findings as json")
+		}
+
+		return append(bytes, '\n'), nil
+
+	case "text":
+
+		var buffer bytes.Buffer
+
+		writer := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+		for _, finding := range findings {
+
+			description := fmt.Sprintf("%s %s", finding.Status, finding.Expiration.Format(time.RFC3339))
+			if finding.Status == IssuerExpiring {
+				description = fmt.Sprintf("issuer %s expires %s", finding.Issuer, finding.IssuerExpiration.Format(time.RFC3339))
+			}
+
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", finding.Fingerprint, finding.Type, finding.CommonName, description)
+		}
+
+		writer.Flush()
+
+		return buffer.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("error parsing output [%s] must be one of [json, text]", output)
+	}
+}
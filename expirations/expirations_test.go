@@ -0,0 +1,141 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expirations
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/filesystem"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExpirations(t *testing.T) {
+
+	Convey("Check", t, func() {
+
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		Convey("when nothing expires within the window", func() {
+
+			findings, err := Check(leaves, "leaf", time.Now(), 30*time.Minute)
+
+			Convey("it returns no findings", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldBeEmpty)
+			})
+		})
+
+		Convey("when a leaf expires within the window", func() {
+
+			findings, err := Check(leaves, "leaf", time.Now(), 2*time.Hour)
+
+			Convey("it returns an expiring finding", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldHaveLength, 1)
+				So(findings[0].Fingerprint, ShouldEqual, fingerprint)
+				So(findings[0].Status, ShouldEqual, Expiring)
+			})
+		})
+
+		Convey("when a leaf has expired", func() {
+
+			findings, err := Check(leaves, "leaf", time.Now().Add(2*time.Hour), 0)
+
+			Convey("it returns an expired finding", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldHaveLength, 1)
+				So(findings[0].Status, ShouldEqual, Expired)
+			})
+		})
+
+		Convey("when a leaf outlives its issuing authority", func() {
+
+			key, err := keys.Generate(keys.ECDSA, 0, "P256")
+			So(err, ShouldBeNil)
+
+			leaf, err := authority.Issue(identities.Template{
+				NotAfter:     time.Now().Add(48 * time.Hour),
+				NotBefore:    time.Now(),
+				SerialNumber: big.NewInt(tests.Random.Int63()),
+				Subject:      pkix.Name{CommonName: "acert.test"},
+			}, key)
+			So(err, ShouldBeNil)
+
+			outliving, err := leaves.Upsert(leaf)
+			So(err, ShouldBeNil)
+
+			findings, err := Check(leaves, "leaf", time.Now(), 2*time.Hour)
+
+			Convey("it returns an issuer expiring finding", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldHaveLength, 2)
+				So(findings[1].Fingerprint, ShouldEqual, outliving)
+				So(findings[1].Status, ShouldEqual, IssuerExpiring)
+				So(findings[1].Issuer, ShouldEqual, certificates.Fingerprint(authority.Certificate))
+			})
+		})
+
+		Convey("when a leaf has been renewed", func() {
+
+			renewal, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+			So(stores.Link(leaves, fingerprint, renewal), ShouldBeNil)
+
+			findings, err := Check(leaves, "leaf", time.Now(), 2*time.Hour)
+
+			Convey("it skips the renewed leaf", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldHaveLength, 1)
+				So(findings[0].Fingerprint, ShouldEqual, renewal)
+			})
+		})
+
+		Convey("when the store is encrypted", func() {
+
+			directory, err := ioutil.TempDir("", "expirations")
+			So(err, ShouldBeNil)
+
+			encrypted := filesystem.NewIdentityStore(directory)
+
+			_, err = encrypted.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+			So(encrypted.Encrypt([]byte("passphrase")), ShouldBeNil)
+
+			findings, err := Check(encrypted.WithSecret(func() ([]byte, error) {
+				return nil, fmt.Errorf("error reading secret not permitted")
+			}), "leaf", time.Now(), 2*time.Hour)
+
+			Convey("it returns the findings without the secret", func() {
+				So(err, ShouldBeNil)
+				So(findings, ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Chain returns the authorities of a certificate ordered from its issuer to its root.  The authorities are selected
+// from the candidates and the anchors and the certificate is verified against them.  Only the self signed candidates
+// and the anchors are trusted as roots (i.e., a chain that does not end at one of them is rejected).
+func Chain(certificate *x509.Certificate, candidates []*x509.Certificate, anchors []*x509.Certificate) ([]*x509.Certificate, error) {
+
+	if SelfSigned(certificate) {
+		return []*x509.Certificate{}, nil
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	trusted := len(anchors)
+
+	for _, anchor := range anchors {
+		roots.AddCert(anchor)
+	}
+
+	for _, candidate := range candidates {
+
+		intermediates.AddCert(candidate)
+
+		if SelfSigned(candidate) {
+			roots.AddCert(candidate)
+			trusted++
+		}
+	}
+
+	if trusted == 0 {
+		return nil, fmt.Errorf("error verifying certificate [%s] no self signed authority or anchor to trust as a root", certificate.Subject.CommonName)
+	}
+
+	chains, err := certificate.Verify(x509.VerifyOptions{
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		Roots:         roots,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error verifying certificate [%s]", certificate.Subject.CommonName)
+	}
+
+	longest := chains[0]
+	for _, chain := range chains {
+		if len(chain) > len(longest) {
+			longest = chain
+		}
+	}
+
+	return longest[1:], nil
+}
+
+// SelfSigned returns true if a certificate is signed by its own key.
+func SelfSigned(certificate *x509.Certificate) bool {
+	return bytes.Equal(certificate.RawIssuer, certificate.RawSubject) &&
+		certificate.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature) == nil
+}
+
+// Verify returns an error if the key of this identity does not match its certificate or its certificate is not
+// verified by its authorities.  The certificate of an identity without authorities is not verified (e.g., an anchor).
+func (i *Identity) Verify() error {
+
+	if i.Key != nil {
+
+		match, err := matches(i.Certificate.PublicKey, i.Key.Public())
+		if err != nil {
+			return err
+		}
+
+		if !match {
+			return fmt.Errorf("error verifying identity [%s] key does not match certificate", i.Certificate.Subject.CommonName)
+		}
+	}
+
+	if SelfSigned(i.Certificate) || len(i.Authorities) == 0 {
+		return nil
+	}
+
+	// The last authority anchors the chain since only the consistency of the identity (not its trust) is verified.
+	_, err := Chain(i.Certificate, i.Authorities, i.Authorities[len(i.Authorities)-1:])
+
+	return err
+}
+
+// matches returns true if two public keys are equal.
+func matches(a, b crypto.PublicKey) (bool, error) {
+
+	x, err := x509.MarshalPKIXPublicKey(a)
+	if err != nil {
+		return false, errors.Wrapf(err, "error marshalling key of type [%T]", a)
+	}
+
+	y, err := x509.MarshalPKIXPublicKey(b)
+	if err != nil {
+		return false, errors.Wrapf(err, "error marshalling key of type [%T]", b)
+	}
+
+	return bytes.Equal(x, y), nil
+}
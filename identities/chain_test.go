@@ -0,0 +1,126 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities_test
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChain(t *testing.T) {
+
+	Convey("Chain", t, func() {
+
+		root := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, root, keys.ECDSA, "P256")
+
+		Convey("when the issuer is a candidate", func() {
+
+			chain, err := identities.Chain(leaf.Certificate, []*x509.Certificate{other.Certificate, root.Certificate}, nil)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the issuer", func() {
+				So(chain, ShouldHaveLength, 1)
+				So(chain[0].Raw, ShouldResemble, root.Certificate.Raw)
+			})
+		})
+
+		Convey("when the issuer is not a candidate", func() {
+
+			chain, err := identities.Chain(leaf.Certificate, []*x509.Certificate{other.Certificate}, nil)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil chain", func() {
+				So(chain, ShouldBeNil)
+			})
+		})
+
+		Convey("when the chain does not end at a self signed candidate", func() {
+
+			intermediate := tests.MustGenerateIntermediate(t, root, keys.ECDSA, "P256", time.Now())
+			issued := tests.MustGenerateLeaf(t, intermediate, keys.ECDSA, "P256")
+
+			chain, err := identities.Chain(issued.Certificate, []*x509.Certificate{intermediate.Certificate}, nil)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil chain", func() {
+				So(chain, ShouldBeNil)
+			})
+
+			Convey("when the chain ends at an anchor", func() {
+
+				chain, err := identities.Chain(issued.Certificate, nil, []*x509.Certificate{intermediate.Certificate})
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the chain to the anchor", func() {
+					So(chain, ShouldHaveLength, 1)
+					So(chain[0].Raw, ShouldResemble, intermediate.Certificate.Raw)
+				})
+			})
+		})
+
+		Convey("when the certificate is self signed", func() {
+
+			chain, err := identities.Chain(root.Certificate, nil, nil)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns an empty chain", func() {
+				So(chain, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Identity.Verify", t, func() {
+
+		root := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, root, keys.Ed25519, "")
+
+		Convey("when the key matches the certificate", func() {
+
+			Convey("it returns a nil error", func() {
+				So(leaf.Verify(), ShouldBeNil)
+			})
+		})
+
+		Convey("when the key does not match the certificate", func() {
+
+			Convey("it returns a non-nil error", func() {
+				So(identities.NewIdentity(leaf.Authorities, leaf.Certificate, root.Key).Verify(), ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RevocationList returns a certificate revocation list for the revoked certificates signed by this identity.
+func (i *Identity) RevocationList(revoked []x509.RevocationListEntry, number *big.Int, thisUpdate time.Time, nextUpdate time.Time, algorithm x509.SignatureAlgorithm) (*x509.RevocationList, error) {
+
+	bytes, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		NextUpdate:                nextUpdate,
+		Number:                    number,
+		RevokedCertificateEntries: revoked,
+		SignatureAlgorithm:        algorithm,
+		ThisUpdate:                thisUpdate,
+	}, i.Certificate, i.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error signing revocation list for [%s]", i.Certificate.Subject.CommonName)
+	}
+
+	list, err := x509.ParseRevocationList(bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing revocation list for [%s]", i.Certificate.Subject.CommonName)
+	}
+
+	return list, nil
+}
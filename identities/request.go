@@ -0,0 +1,200 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var (
+
+	// oidExtensionBasicConstraints defines the object identifier of the basic constraints extension.
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+
+	// oidExtensionExtendedKeyUsage defines the object identifier of the extended key usage extension.
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+
+	// oidExtensionKeyUsage defines the object identifier of the key usage extension.
+	oidExtensionKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 15}
+
+	// oidExtKeyUsages defines the object identifiers of the supported extended key usages.
+	oidExtKeyUsages = map[x509.ExtKeyUsage]asn1.ObjectIdentifier{
+		x509.ExtKeyUsageClientAuth: {1, 3, 6, 1, 5, 5, 7, 3, 2},
+		x509.ExtKeyUsageServerAuth: {1, 3, 6, 1, 5, 5, 7, 3, 1},
+	}
+)
+
+// Request represents a pending X.509 identity (i.e., a certificate request and its key) awaiting a certificate from
+// an external authority.
+type Request struct {
+	CertificateRequest *x509.CertificateRequest
+	Key                crypto.Signer
+}
+
+// NewRequest returns a new request for the provided key based upon a template.  The subject, subject alternative
+// names, basic constraints and key usages of the template are requested.
+func NewRequest(template Template, key crypto.Signer) (*Request, error) {
+
+	extensions, err := requestExtensions(template)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating certificate request for [%s]", template.Subject.CommonName)
+	}
+
+	bytes, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		DNSNames:           template.DNSNames,
+		EmailAddresses:     template.EmailAddresses,
+		ExtraExtensions:    extensions,
+		IPAddresses:        template.IPAddresses,
+		SignatureAlgorithm: template.SignatureAlgorithm,
+		Subject:            template.Subject,
+		URIs:               template.URIs,
+	}, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating certificate request for [%s]", template.Subject.CommonName)
+	}
+
+	request, err := x509.ParseCertificateRequest(bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing certificate request for [%s]", template.Subject.CommonName)
+	}
+
+	return &Request{CertificateRequest: request, Key: key}, nil
+}
+
+// Complete returns the identity for a certificate issued for this request.  The authorities of the identity are
+// selected from the candidates and the anchors (see Chain).
+func (r *Request) Complete(certificate *x509.Certificate, candidates []*x509.Certificate, anchors []*x509.Certificate) (*Identity, error) {
+
+	if !r.Matches(certificate) {
+		return nil, fmt.Errorf("error completing request [%s] key does not match certificate", certificate.Subject.CommonName)
+	}
+
+	authorities, err := Chain(certificate, candidates, anchors)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error completing request [%s]", certificate.Subject.CommonName)
+	}
+
+	return NewIdentity(authorities, certificate, r.Key), nil
+}
+
+// Matches returns true if the key of this request matches the key of a certificate.
+func (r *Request) Matches(certificate *x509.Certificate) bool {
+	match, err := matches(certificate.PublicKey, r.Key.Public())
+	return err == nil && match
+}
+
+// requestExtensions returns the basic constraints and key usage extensions requested by a template.
+func requestExtensions(template Template) ([]pkix.Extension, error) {
+
+	var extensions []pkix.Extension
+
+	if template.BasicConstraintsValid {
+
+		value, err := asn1.Marshal(struct {
+			IsCA       bool `asn1:"optional"`
+			MaxPathLen int  `asn1:"optional,default:-1"`
+		}{template.IsCA, -1})
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshalling basic constraints")
+		}
+
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionBasicConstraints, Critical: true, Value: value})
+	}
+
+	if template.KeyUsage != 0 {
+
+		var bytes [2]byte
+		bytes[0] = reverse(byte(template.KeyUsage))
+		bytes[1] = reverse(byte(template.KeyUsage >> 8))
+
+		length := 1
+		if bytes[1] != 0 {
+			length = 2
+		}
+
+		value, err := asn1.Marshal(asn1.BitString{Bytes: bytes[:length], BitLength: bitLength(bytes[:length])})
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshalling key usage")
+		}
+
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionKeyUsage, Critical: true, Value: value})
+	}
+
+	if len(template.ExtKeyUsage) > 0 {
+
+		identifiers := make([]asn1.ObjectIdentifier, len(template.ExtKeyUsage))
+
+		for index, usage := range template.ExtKeyUsage {
+
+			identifier, found := oidExtKeyUsages[usage]
+			if !found {
+				return nil, fmt.Errorf("error marshalling extended key usage [%d] is not supported", usage)
+			}
+
+			identifiers[index] = identifier
+		}
+
+		value, err := asn1.Marshal(identifiers)
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshalling extended key usage")
+		}
+
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionExtendedKeyUsage, Value: value})
+	}
+
+	return extensions, nil
+}
+
+// bitLength returns the length in bits of a bit string without its trailing zeros.
+func bitLength(bytes []byte) int {
+
+	length := len(bytes) * 8
+
+	for index := range bytes {
+
+		b := bytes[len(bytes)-index-1]
+
+		for bit := 0; bit < 8; bit++ {
+
+			if (b>>uint(bit))&1 == 1 {
+				return length
+			}
+
+			length--
+		}
+	}
+
+	return 0
+}
+
+// reverse returns a byte with its bits in reverse order.
+func reverse(b byte) byte {
+
+	var reversed byte
+
+	for bit := 0; bit < 8; bit++ {
+		reversed = reversed<<1 | b&1
+		b >>= 1
+	}
+
+	return reversed
+}
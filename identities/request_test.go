@@ -0,0 +1,102 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequest(t *testing.T) {
+
+	Convey("NewRequest", t, func() {
+
+		key, err := keys.Generate(keys.ECDSA, 0, "P256")
+		if err != nil {
+			t.Fail()
+		}
+
+		request, err := identities.NewRequest(identities.Template{
+			BasicConstraintsValid: true,
+			DNSNames:              []string{"acert.test"},
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			Subject:               pkix.Name{CommonName: "acert.test"},
+		}, key)
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it returns a signed request for the template", func() {
+			So(request.CertificateRequest.CheckSignature(), ShouldBeNil)
+			So(request.CertificateRequest.Subject.CommonName, ShouldEqual, "acert.test")
+			So(request.CertificateRequest.DNSNames, ShouldResemble, []string{"acert.test"})
+			So(request.CertificateRequest.Extensions, ShouldHaveLength, 3)
+		})
+
+		Convey(".Complete", func() {
+
+			authority := tests.MustGenerateAuthority(t, keys.RSA, "")
+			other := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+			signed, err := authority.Sign(identities.Template{
+				BasicConstraintsValid: true,
+				IsCA:                  true,
+				KeyUsage:              x509.KeyUsageCertSign,
+				NotAfter:              authority.Certificate.NotAfter,
+				NotBefore:             authority.Certificate.NotBefore,
+				SerialNumber:          other.Certificate.SerialNumber,
+				Subject:               request.CertificateRequest.Subject,
+			}, request.CertificateRequest.PublicKey)
+			if err != nil {
+				t.Fail()
+			}
+
+			Convey("when the certificate matches the key", func() {
+
+				identity, err := request.Complete(signed.Certificate, []*x509.Certificate{authority.Certificate}, nil)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the identity", func() {
+					So(identity.Key, ShouldEqual, key)
+					So(identity.Authorities[0].Raw, ShouldResemble, authority.Certificate.Raw)
+				})
+			})
+
+			Convey("when the certificate does not match the key", func() {
+
+				identity, err := request.Complete(other.Certificate, []*x509.Certificate{authority.Certificate}, nil)
+
+				Convey("it returns a non-nil error", func() {
+					So(err, ShouldNotBeNil)
+				})
+
+				Convey("it returns a nil identity", func() {
+					So(identity, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
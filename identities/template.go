@@ -0,0 +1,106 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+)
+
+// Template defines the values used to create a certificate for an identity.
+type Template struct {
+	AuthorityKeyID              []byte
+	BasicConstraintsValid       bool
+	CRLDistributionPoints       []string
+	DNSNames                    []string
+	EmailAddresses              []string
+	ExcludedDNSDomains          []string
+	ExcludedEmailAddresses      []string
+	ExcludedIPRanges            []*net.IPNet
+	ExcludedURIDomains          []string
+	ExtKeyUsage                 []x509.ExtKeyUsage
+	ExtraExtensions             []pkix.Extension
+	IPAddresses                 []net.IP
+	IsCA                        bool
+	IssuingCertificateURL       []string
+	KeyUsage                    x509.KeyUsage
+	MaxPathLen                  int
+	MaxPathLenZero              bool
+	NotAfter                    time.Time
+	NotBefore                   time.Time
+	OCSPServer                  []string
+	PermittedDNSDomains         []string
+	PermittedDNSDomainsCritical bool
+	PermittedEmailAddresses     []string
+	PermittedIPRanges           []*net.IPNet
+	PermittedURIDomains         []string
+	PolicyIdentifiers           []asn1.ObjectIdentifier
+	SerialNumber                *big.Int
+	SignatureAlgorithm          x509.SignatureAlgorithm
+	Subject                     pkix.Name
+	SubjectKeyID                []byte
+	URIs                        []*url.URL
+	UnknownExtKeyUsage          []asn1.ObjectIdentifier
+}
+
+// certificate returns the X.509 certificate template for this template.
+func (t *Template) certificate() *x509.Certificate {
+	return &x509.Certificate{
+		AuthorityKeyId:              t.AuthorityKeyID,
+		BasicConstraintsValid:       t.BasicConstraintsValid,
+		CRLDistributionPoints:       t.CRLDistributionPoints,
+		DNSNames:                    t.DNSNames,
+		EmailAddresses:              t.EmailAddresses,
+		ExcludedDNSDomains:          t.ExcludedDNSDomains,
+		ExcludedEmailAddresses:      t.ExcludedEmailAddresses,
+		ExcludedIPRanges:            t.ExcludedIPRanges,
+		ExcludedURIDomains:          t.ExcludedURIDomains,
+		ExtKeyUsage:                 t.ExtKeyUsage,
+		ExtraExtensions:             t.ExtraExtensions,
+		IPAddresses:                 t.IPAddresses,
+		IsCA:                        t.IsCA,
+		IssuingCertificateURL:       t.IssuingCertificateURL,
+		KeyUsage:                    t.KeyUsage,
+		MaxPathLen:                  t.MaxPathLen,
+		MaxPathLenZero:              t.MaxPathLenZero,
+		NotAfter:                    t.NotAfter,
+		NotBefore:                   t.NotBefore,
+		OCSPServer:                  t.OCSPServer,
+		PermittedDNSDomains:         t.PermittedDNSDomains,
+		PermittedDNSDomainsCritical: t.PermittedDNSDomainsCritical,
+		PermittedEmailAddresses:     t.PermittedEmailAddresses,
+		PermittedIPRanges:           t.PermittedIPRanges,
+		PermittedURIDomains:         t.PermittedURIDomains,
+		PolicyIdentifiers:           t.PolicyIdentifiers,
+		SerialNumber:                t.SerialNumber,
+		SignatureAlgorithm:          t.SignatureAlgorithm,
+		Subject:                     t.Subject,
+		SubjectKeyId:                t.SubjectKeyID,
+		URIs:                        t.URIs,
+		UnknownExtKeyUsage:          t.UnknownExtKeyUsage,
+	}
+}
+
+// Limit limits the expiration of this template to the expiration of the authority issuing it.
+func (t *Template) Limit(authority *x509.Certificate) {
+	if t.NotAfter.After(authority.NotAfter) {
+		t.NotAfter = authority.NotAfter
+	}
+}
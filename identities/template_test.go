@@ -0,0 +1,55 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTemplate(t *testing.T) {
+
+	Convey("Limit", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		Convey("when the template expires after the authority", func() {
+
+			template := identities.Template{NotAfter: authority.Certificate.NotAfter.Add(time.Hour)}
+			template.Limit(authority.Certificate)
+
+			Convey("it limits the expiration to the expiration of the authority", func() {
+				So(template.NotAfter, ShouldEqual, authority.Certificate.NotAfter)
+			})
+		})
+
+		Convey("when the template expires before the authority", func() {
+
+			expires := authority.Certificate.NotAfter.Add(-time.Hour)
+
+			template := identities.Template{NotAfter: expires}
+			template.Limit(authority.Certificate)
+
+			Convey("it keeps the expiration", func() {
+				So(template.NotAfter, ShouldEqual, expires)
+			})
+		})
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chains
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/files"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+// Options defines the options for reading a certificate and its chain (e.g., to complete a request or import an
+// identity).
+type Options struct {
+
+	// Anchors defines the path to the PEM encoded certificates trusted as roots in addition to the self signed
+	// authorities.
+	Anchors string `mapstructure:"anchors"`
+
+	// Certificate defines the path to the PEM encoded certificate optionally followed by its chain.
+	Certificate string `mapstructure:"certificate"`
+
+	// Chain defines the path to the PEM encoded chain of the certificate.
+	Chain string `mapstructure:"chain"`
+}
+
+// Stdin returns true if one of the files defined by these options is read from stdin.
+func (o Options) Stdin() bool {
+	return o.Anchors == files.Stdin || o.Certificate == files.Stdin || o.Chain == files.Stdin
+}
+
+// Anchors returns the certificates trusted as roots from the file defined by the options (if any).
+func Anchors(options Options) ([]*x509.Certificate, error) {
+
+	if options.Anchors == "" {
+		return nil, nil
+	}
+
+	bytes, err := files.Read(options.Anchors)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates, err := encoding.PEMDecodeCertificates(bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding anchors from [%s]", options.Anchors)
+	}
+
+	return certificates, nil
+}
+
+// Candidates returns the certificates of the stored authorities and their chains without reading their keys.
+func Candidates(authorities stores.IdentityStore) ([]*x509.Certificate, error) {
+
+	records, err := authorities.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []*x509.Certificate
+
+	for _, record := range records {
+		certificates = append(certificates, record.Certificate)
+		certificates = append(certificates, record.Authorities...)
+	}
+
+	return certificates, nil
+}
+
+// Find returns the pending request with the key of a certificate.
+func Find(requests stores.RequestStore, certificate *x509.Certificate) (*identities.Request, error) {
+
+	pending, err := requests.ListRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, request := range pending {
+		if request.Matches(certificate) {
+			return request, nil
+		}
+	}
+
+	return nil, fmt.Errorf("error completing request [%s] no pending request matches the key of the certificate", certificate.Subject.CommonName)
+}
+
+// Read returns the certificate and chain from the files defined by the options.
+func Read(options Options) (*x509.Certificate, []*x509.Certificate, error) {
+
+	if options.Certificate == files.Stdin && options.Chain == files.Stdin ||
+		options.Anchors == files.Stdin && (options.Certificate == files.Stdin || options.Chain == files.Stdin) {
+		return nil, nil, fmt.Errorf("error reading certificate stdin may only be used for one file")
+	}
+
+	bytes, err := files.Read(options.Certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificates, err := encoding.PEMDecodeCertificates(bytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error decoding certificate from [%s]", options.Certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, nil, fmt.Errorf("error decoding certificate from [%s] no certificates defined", options.Certificate)
+	}
+
+	chain := certificates[1:]
+
+	if options.Chain != "" {
+
+		bytes, err = files.Read(options.Chain)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		authorities, err := encoding.PEMDecodeCertificates(bytes)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error decoding chain from [%s]", options.Chain)
+		}
+
+		chain = append(chain, authorities...)
+	}
+
+	return certificates[0], chain, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChains(t *testing.T) {
+
+	Convey("Candidates", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		root := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		intermediate := tests.MustGenerateIntermediate(t, root, keys.ECDSA, "P256", time.Now())
+
+		_, err := authorities.Upsert(intermediate)
+		So(err, ShouldBeNil)
+
+		candidates, err := Candidates(authorities)
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it returns the stored authorities and their chains", func() {
+			So(candidates, ShouldHaveLength, 2)
+			So(candidates[0].Equal(intermediate.Certificate), ShouldBeTrue)
+			So(candidates[1].Equal(root.Certificate), ShouldBeTrue)
+		})
+	})
+
+	Convey("Find", t, func() {
+
+		requests := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		key, err := keys.Generate(keys.ECDSA, 0, "P256")
+		So(err, ShouldBeNil)
+
+		request, err := identities.NewRequest(identities.Template{}, key)
+		So(err, ShouldBeNil)
+
+		_, err = requests.UpsertRequest(request)
+		So(err, ShouldBeNil)
+
+		Convey("when a pending request matches the key of the certificate", func() {
+
+			serial, err := serials.Generate()
+			So(err, ShouldBeNil)
+
+			signed, err := authority.Sign(identities.Template{NotAfter: time.Now().Add(time.Hour), SerialNumber: serial}, key.Public())
+			So(err, ShouldBeNil)
+
+			found, err := Find(requests, signed.Certificate)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the request", func() {
+				So(found, ShouldEqual, request)
+			})
+		})
+
+		Convey("when no pending request matches the key of the certificate", func() {
+
+			found, err := Find(requests, authority.Certificate)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil request", func() {
+				So(found, ShouldBeNil)
+			})
+		})
+	})
+}
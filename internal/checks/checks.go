@@ -0,0 +1,82 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/greymatter-io/acert/expirations"
+	"github.com/greymatter-io/acert/internal/durations"
+	"github.com/greymatter-io/acert/stores"
+)
+
+// Options defines the options for checking identities for expirations.
+type Options struct {
+
+	// Output defines the format of the findings (i.e., json or text).
+	Output string `mapstructure:"output"`
+
+	// Within defines the window in which expirations need attention (e.g., 30d).
+	Within string `mapstructure:"within"`
+}
+
+// Target defines the identities of a type (e.g., authority or leaf) in a store that are checked.
+type Target struct {
+
+	// Store defines the store of the identities.
+	Store stores.IdentityStore
+
+	// Type defines the type of the identities.
+	Type string
+}
+
+// Run writes the findings for the targets that expire within the window defined by the options (see
+// expirations.Check) to a writer.  An error is returned if any identities need attention.
+func Run(writer io.Writer, options Options, now time.Time, targets ...Target) error {
+
+	within, err := durations.Parse(options.Within)
+	if err != nil {
+		return err
+	}
+
+	findings := []*expirations.Finding{}
+
+	for _, target := range targets {
+
+		found, err := expirations.Check(target.Store, target.Type, now, within)
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, found...)
+	}
+
+	expirations.Sort(findings)
+
+	bytes, err := expirations.Format(findings, options.Output)
+	if err != nil {
+		return err
+	}
+
+	writer.Write(bytes)
+
+	if len(findings) > 0 {
+		return fmt.Errorf("error checking expirations [%d] identities need attention", len(findings))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/expirations"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChecks(t *testing.T) {
+
+	Convey("Run", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		_, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		targets := []Target{{Store: authorities, Type: "authority"}, {Store: leaves, Type: "leaf"}}
+
+		var buffer bytes.Buffer
+
+		Convey("when nothing expires within the window", func() {
+
+			err := Run(&buffer, Options{Output: "json", Within: "30m"}, time.Now(), targets...)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it writes no findings", func() {
+				So(buffer.String(), ShouldEqual, "[]\n")
+			})
+		})
+
+		Convey("when identities expire within the window", func() {
+
+			err := Run(&buffer, Options{Output: "json", Within: "1d"}, time.Now(), targets...)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it writes the findings of every target", func() {
+
+				findings := []*expirations.Finding{}
+				So(json.Unmarshal(buffer.Bytes(), &findings), ShouldBeNil)
+
+				types := map[string]string{}
+				for _, finding := range findings {
+					types[finding.Fingerprint] = finding.Type
+				}
+
+				So(types, ShouldResemble, map[string]string{
+					certificates.Fingerprint(authority.Certificate): "authority",
+					fingerprint: "leaf",
+				})
+			})
+		})
+
+		Convey("when the window is negative", func() {
+
+			err := Run(&buffer, Options{Output: "text", Within: "-1h"}, time.Now(), targets...)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it writes nothing", func() {
+				So(buffer.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("when the output is unknown", func() {
+
+			err := Run(&buffer, Options{Output: "yaml", Within: "30d"}, time.Now(), targets...)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package durations
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// days matches a leading number of days (e.g., the 30d of 30d12h).
+var days = regexp.MustCompile(`^(\d+)d`)
+
+// Parse returns the duration for a value in the format accepted by time.ParseDuration with the addition of a leading
+// number of days (e.g., 30d or 1d12h).  Negative durations are rejected.
+func Parse(value string) (time.Duration, error) {
+
+	var duration time.Duration
+
+	remainder := value
+
+	match := days.FindStringSubmatch(remainder)
+	if match != nil {
+
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("error parsing duration [%s] must be a number of days, hours, minutes or seconds (e.g., 30d or 12h)", value)
+		}
+
+		duration = time.Duration(count) * 24 * time.Hour
+		remainder = remainder[len(match[0]):]
+	}
+
+	if match == nil || remainder != "" {
+
+		parsed, err := time.ParseDuration(remainder)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing duration [%s] must be a number of days, hours, minutes or seconds (e.g., 30d or 12h)", value)
+		}
+
+		duration += parsed
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("error parsing duration [%s] must not be negative", value)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package durations
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDurations(t *testing.T) {
+
+	Convey("Parse", t, func() {
+
+		Convey("when the value is valid it returns the duration", func() {
+
+			valid := map[string]time.Duration{
+				"30d":     30 * 24 * time.Hour,
+				"1d12h":   36 * time.Hour,
+				"72h":     72 * time.Hour,
+				"90m":     90 * time.Minute,
+				"0":       0,
+				"0d":      0,
+				"1d30m5s": 24*time.Hour + 30*time.Minute + 5*time.Second,
+			}
+
+			for value, expected := range valid {
+				duration, err := Parse(value)
+				So(err, ShouldBeNil)
+				So(duration, ShouldEqual, expected)
+			}
+		})
+
+		Convey("when the value is negative it returns an error", func() {
+
+			for _, value := range []string{"-1h", "-1d", "1d-25h"} {
+				_, err := Parse(value)
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("when the value is not a duration it returns an error", func() {
+
+			for _, value := range []string{"", "d", "30", "30days", "1w", "abc", "1d1d"} {
+				_, err := Parse(value)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exports
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/prompts"
+	"github.com/greymatter-io/acert/pkcs12"
+)
+
+// Options defines the options for exporting an identity.
+type Options struct {
+
+	// Type defines the type of artifact to export.
+	Type string `mapstructure:"type"`
+
+	// Format defines the format of the artifact to export (i.e., der, p12, pem, pkcs1 or pkcs8).
+	Format string `mapstructure:"format"`
+
+	// FriendlyName defines the friendly name (alias) of the entries of a PKCS #12 trust store export.
+	FriendlyName string `mapstructure:"friendlyName"`
+
+	// Password defines the password protecting a PKCS #12 export.
+	Password string `mapstructure:"password"`
+}
+
+// Encode returns the certificates or key of an identity in the format defined by the options (see encode and
+// encodePKCS12).
+func Encode(identity *identities.Identity, options Options) ([]byte, error) {
+
+	if strings.EqualFold(options.Format, encoding.PKCS12) {
+		return encodePKCS12(identity, options)
+	}
+
+	return encode(identity, options)
+}
+
+// encode returns the certificates or key of an identity in the format defined by the options.
+func encode(identity *identities.Identity, options Options) ([]byte, error) {
+
+	switch strings.ToLower(options.Type) {
+	case "authority":
+		return encoding.EncodeCertificates(identity.Authorities, options.Format)
+	case "certificate":
+		return encoding.EncodeCertificate(identity.Certificate, options.Format)
+	case "key":
+
+		if identity.Key == nil {
+			return nil, fmt.Errorf("error exporting key for [%s] the key is held by the requester", certificates.Fingerprint(identity.Certificate))
+		}
+
+		return encoding.EncodeKey(identity.Key, options.Format)
+	default:
+		return nil, fmt.Errorf("error parsing type [%s] must be one of [authority, certificate, key]", options.Type)
+	}
+}
+
+// encodePKCS12 returns a PKCS #12 trust store containing the authorities of an identity (i.e., for the authority type)
+// or a PKCS #12 key store containing the key, certificate and authorities of an identity.
+func encodePKCS12(identity *identities.Identity, options Options) ([]byte, error) {
+
+	switch strings.ToLower(options.Type) {
+	case "authority", "certificate", "key":
+	default:
+		return nil, fmt.Errorf("error parsing type [%s] must be one of [authority, certificate, key]", options.Type)
+	}
+
+	if identity.Key == nil && !strings.EqualFold(options.Type, "authority") {
+		return nil, fmt.Errorf("error exporting key store for [%s] the key is held by the requester", certificates.Fingerprint(identity.Certificate))
+	}
+
+	password := options.Password
+
+	if password == "" {
+
+		entered, err := prompts.NewPassword("Export password")
+		if err != nil {
+			return nil, err
+		}
+
+		password = entered
+	}
+
+	if strings.EqualFold(options.Type, "authority") {
+		return pkcs12.EncodeTrustStore(identity.Authorities, password, options.FriendlyName)
+	}
+
+	return pkcs12.Encode(identity.Key, identity.Certificate, identity.Authorities, password)
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package files
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Stdin defines the path used to read from standard input.
+const Stdin = "-"
+
+// Read returns the contents of the file at a path or of standard input if the path is "-".
+func Read(path string) ([]byte, error) {
+
+	if path == Stdin {
+
+		bytes, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading from stdin")
+		}
+
+		return bytes, nil
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading from [%s]", path)
+	}
+
+	return bytes, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// Password prints a prompt to stderr and returns a password read from the terminal without echoing it.
+func Password(prompt string) (string, error) {
+
+	descriptor := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(descriptor) {
+		return "", fmt.Errorf("error reading [%s] standard input is not a terminal", prompt)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+
+	bytes, err := term.ReadPassword(descriptor)
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading [%s]", prompt)
+	}
+
+	return string(bytes), nil
+}
+
+// NewPassword prompts for a password twice and returns it if both entries match.
+func NewPassword(prompt string) (string, error) {
+
+	password, err := Password(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	verification, err := Password(fmt.Sprintf("Verify %s", prompt))
+	if err != nil {
+		return "", err
+	}
+
+	if password != verification {
+		return "", fmt.Errorf("error reading [%s] entries do not match", prompt)
+	}
+
+	return password, nil
+}
+
+// Confirm prints a yes or no question to stderr and returns true if the answer read from the terminal is yes.
+func Confirm(prompt string) (bool, error) {
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("error reading [%s] standard input is not a terminal", prompt)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, errors.Wrapf(err, "error reading [%s]", prompt)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
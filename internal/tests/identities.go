@@ -0,0 +1,112 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/serials"
+)
+
+// MustGenerateAuthority generates a self signed authority with a key of the provided type or fails a test.
+func MustGenerateAuthority(t *testing.T, keyType string, curve string) *identities.Identity {
+
+	key, err := keys.Generate(keyType, 0, curve)
+	if err != nil {
+		t.Fatalf("error generating key for authority: %v", err)
+	}
+
+	authority, err := identities.Self(identities.Template{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		NotAfter:              time.Now().Add(time.Hour),
+		NotBefore:             time.Now(),
+		SerialNumber:          mustGenerateSerial(t),
+		Subject:               pkix.Name{CommonName: "Acert (Test)"},
+	}, key)
+	if err != nil {
+		t.Fatalf("error generating authority: %v", err)
+	}
+
+	return authority
+}
+
+// MustGenerateIntermediate issues an intermediate authority valid from the provided time for an hour from an authority
+// with a key of the provided type or fails a test.
+func MustGenerateIntermediate(t *testing.T, authority *identities.Identity, keyType string, curve string, from time.Time) *identities.Identity {
+
+	key, err := keys.Generate(keyType, 0, curve)
+	if err != nil {
+		t.Fatalf("error generating key for intermediate: %v", err)
+	}
+
+	intermediate, err := authority.Issue(identities.Template{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		NotAfter:              from.Add(time.Hour),
+		NotBefore:             from,
+		SerialNumber:          mustGenerateSerial(t),
+		Subject:               pkix.Name{CommonName: "Acert (Intermediate)"},
+	}, key)
+	if err != nil {
+		t.Fatalf("error generating intermediate: %v", err)
+	}
+
+	return intermediate
+}
+
+// MustGenerateLeaf issues a leaf from an authority with a key of the provided type or fails a test.
+func MustGenerateLeaf(t *testing.T, authority *identities.Identity, keyType string, curve string) *identities.Identity {
+
+	key, err := keys.Generate(keyType, 0, curve)
+	if err != nil {
+		t.Fatalf("error generating key for leaf: %v", err)
+	}
+
+	leaf, err := authority.Issue(identities.Template{
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"acert.test"},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		NotAfter:              time.Now().Add(time.Hour),
+		NotBefore:             time.Now(),
+		SerialNumber:          mustGenerateSerial(t),
+		Subject:               pkix.Name{CommonName: "acert.test"},
+	}, key)
+	if err != nil {
+		t.Fatalf("error generating leaf: %v", err)
+	}
+
+	return leaf
+}
+
+// mustGenerateSerial generates a serial number or fails a test.
+func mustGenerateSerial(t *testing.T) *big.Int {
+
+	serial, err := serials.Generate()
+	if err != nil {
+		t.Fatalf("error generating serial number: %v", err)
+	}
+
+	return serial
+}
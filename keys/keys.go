@@ -0,0 +1,156 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+
+	// ECDSA identifies elliptic curve keys.
+	ECDSA = "ecdsa"
+
+	// Ed25519 identifies Edwards-curve keys.
+	Ed25519 = "ed25519"
+
+	// RSA identifies RSA keys.
+	RSA = "rsa"
+)
+
+// Curve returns the elliptic curve with the provided name (e.g., P256 or P-256).
+func Curve(name string) (elliptic.Curve, error) {
+
+	switch strings.ToUpper(strings.Replace(name, "-", "", -1)) {
+	case "P256":
+		return elliptic.P256(), nil
+	case "P384":
+		return elliptic.P384(), nil
+	case "P521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("error parsing curve [%s] must be one of [P256, P384, P521]", name)
+	}
+}
+
+// Generate returns a new private key of the provided type using the provided size for RSA keys (zero selects the
+// default of 4096 bits) and the provided curve for ECDSA keys.  Keys are returned as a crypto.Signer so that identities
+// may be signed and stored without regard to the underlying algorithm.
+func Generate(keyType string, size int, curve string) (crypto.Signer, error) {
+
+	if size != 0 && !strings.EqualFold(keyType, RSA) {
+		return nil, fmt.Errorf("error parsing key size [%d] only rsa keys support a size", size)
+	}
+
+	switch strings.ToLower(keyType) {
+	case ECDSA:
+
+		parsed, err := Curve(curve)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ecdsa.GenerateKey(parsed, rand.Reader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error generating ecdsa key for curve [%s]", curve)
+		}
+
+		return key, nil
+
+	case Ed25519:
+
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating ed25519 key")
+		}
+
+		return key, nil
+
+	case RSA:
+
+		if size == 0 {
+			size = 4096
+		}
+
+		if size != 2048 && size != 3072 && size != 4096 && size != 8192 {
+			return nil, fmt.Errorf("error parsing key size [%d] must be one of [2048, 3072, 4096, 8192]", size)
+		}
+
+		key, err := rsa.GenerateKey(rand.Reader, size)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error generating rsa key of size [%d]", size)
+		}
+
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("error parsing key type [%s] must be one of [ecdsa, ed25519, rsa]", keyType)
+	}
+}
+
+// GenerateLike returns a new private key of the same type and strength as the provided public key (e.g., to replace a
+// key without changing the algorithm of the identity using it).
+func GenerateLike(public crypto.PublicKey) (crypto.Signer, error) {
+
+	switch public := public.(type) {
+	case *ecdsa.PublicKey:
+		return Generate(ECDSA, 0, public.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return Generate(Ed25519, 0, "")
+	case *rsa.PublicKey:
+		return Generate(RSA, public.N.BitLen(), "")
+	default:
+		return nil, fmt.Errorf("error generating key like [%T] must be one of [ecdsa, ed25519, rsa]", public)
+	}
+}
+
+// Algorithm returns a description of the algorithm and strength of a public key (e.g., RSA-4096 or ECDSA-P256).
+func Algorithm(public crypto.PublicKey) string {
+
+	algorithm, size := Parameters(public)
+
+	switch algorithm {
+	case "ECDSA":
+		return fmt.Sprintf("%s-P%d", algorithm, size)
+	case "RSA":
+		return fmt.Sprintf("%s-%d", algorithm, size)
+	default:
+		return algorithm
+	}
+}
+
+// Parameters returns the algorithm (i.e., ECDSA, ED25519 or RSA) and size in bits of a public key.
+func Parameters(public crypto.PublicKey) (string, int) {
+
+	switch public := public.(type) {
+	case *ecdsa.PublicKey:
+		return "ECDSA", public.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "ED25519", 8 * len(public)
+	case *rsa.PublicKey:
+		return "RSA", public.N.BitLen()
+	default:
+		return "UNKNOWN", 0
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKeys(t *testing.T) {
+
+	Convey("Generate", t, func() {
+
+		Convey("when the key type is ecdsa", func() {
+
+			key, err := Generate("ecdsa", 0, "P-384")
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns an ecdsa key on the curve", func() {
+				So(key, ShouldHaveSameTypeAs, &ecdsa.PrivateKey{})
+				So(Algorithm(key.Public()), ShouldEqual, "ECDSA-P384")
+			})
+
+			Convey("it returns the parameters of the key", func() {
+				algorithm, size := Parameters(key.Public())
+				So(algorithm, ShouldEqual, "ECDSA")
+				So(size, ShouldEqual, 384)
+			})
+		})
+
+		Convey("when the key type is ed25519", func() {
+
+			key, err := Generate("ed25519", 0, "")
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns an ed25519 key", func() {
+				So(key, ShouldHaveSameTypeAs, ed25519.PrivateKey{})
+				So(Algorithm(key.Public()), ShouldEqual, "ED25519")
+			})
+
+			Convey("it returns the parameters of the key", func() {
+				algorithm, size := Parameters(key.Public())
+				So(algorithm, ShouldEqual, "ED25519")
+				So(size, ShouldEqual, 256)
+			})
+		})
+
+		Convey("when the key type is rsa", func() {
+
+			key, err := Generate("RSA", 2048, "")
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns an rsa key", func() {
+				So(key, ShouldHaveSameTypeAs, &rsa.PrivateKey{})
+				So(Algorithm(key.Public()), ShouldEqual, "RSA-2048")
+			})
+		})
+
+		Convey("when the curve is unknown", func() {
+
+			key, err := Generate("ecdsa", 0, "P-999")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil key", func() {
+				So(key, ShouldBeNil)
+			})
+		})
+
+		Convey("when the key size is provided for a non-rsa key", func() {
+
+			key, err := Generate("ecdsa", 2048, "P256")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil key", func() {
+				So(key, ShouldBeNil)
+			})
+		})
+
+		Convey("when the key size is not supported", func() {
+
+			key, err := Generate("rsa", 1024, "")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil key", func() {
+				So(key, ShouldBeNil)
+			})
+		})
+
+		Convey("when the key type is unknown", func() {
+
+			key, err := Generate("dsa", 0, "")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil key", func() {
+				So(key, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("GenerateLike", t, func() {
+
+		Convey("when the public key is ecdsa", func() {
+
+			existing, err := Generate("ecdsa", 0, "P521")
+			So(err, ShouldBeNil)
+
+			key, err := GenerateLike(existing.Public())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a different key on the same curve", func() {
+				So(Algorithm(key.Public()), ShouldEqual, "ECDSA-P521")
+				So(key, ShouldNotResemble, existing)
+			})
+		})
+
+		Convey("when the public key is rsa", func() {
+
+			existing, err := Generate("rsa", 2048, "")
+			So(err, ShouldBeNil)
+
+			key, err := GenerateLike(existing.Public())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a key of the same size", func() {
+				So(Algorithm(key.Public()), ShouldEqual, "RSA-2048")
+			})
+		})
+	})
+
+	Convey("SignatureAlgorithm", t, func() {
+
+		key, err := Generate("ecdsa", 0, "P256")
+		if err != nil {
+			t.Fail()
+		}
+
+		Convey("when the name is empty", func() {
+
+			algorithm, err := SignatureAlgorithm("", key.Public())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the unknown algorithm so the default is selected", func() {
+				So(algorithm, ShouldEqual, x509.UnknownSignatureAlgorithm)
+			})
+		})
+
+		Convey("when the name matches the key type", func() {
+
+			algorithm, err := SignatureAlgorithm("ecdsa-sha384", key.Public())
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the algorithm", func() {
+				So(algorithm, ShouldEqual, x509.ECDSAWithSHA384)
+			})
+		})
+
+		Convey("when the name does not match the key type", func() {
+
+			_, err := SignatureAlgorithm("SHA256-RSAPSS", key.Public())
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+var (
+
+	// signatureAlgorithms defines the signature algorithms supported for each type of signing key.
+	signatureAlgorithms = map[string][]x509.SignatureAlgorithm{
+		ECDSA:   {x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512},
+		Ed25519: {x509.PureEd25519},
+		RSA: {
+			x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+			x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		},
+	}
+)
+
+// SignatureAlgorithm returns the signature algorithm with the provided name (e.g., SHA384-RSA or ECDSA-SHA384) after
+// verifying that it may be used with the provided signing key.  An empty name selects the default for the key.
+func SignatureAlgorithm(name string, public crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+
+	keyType := Type(public)
+
+	if name == "" {
+		return x509.UnknownSignatureAlgorithm, nil
+	}
+
+	supported := signatureAlgorithms[keyType]
+
+	names := make([]string, len(supported))
+	for index, algorithm := range supported {
+
+		if strings.EqualFold(algorithm.String(), name) {
+			return algorithm, nil
+		}
+
+		names[index] = algorithm.String()
+	}
+
+	return x509.UnknownSignatureAlgorithm, fmt.Errorf("error parsing signature algorithm [%s] for %s key must be one of [%s]", name, keyType, strings.Join(names, ", "))
+}
+
+// Type returns the type of a public key (i.e., ecdsa, ed25519 or rsa).
+func Type(public crypto.PublicKey) string {
+
+	switch public.(type) {
+	case *ecdsa.PublicKey:
+		return ECDSA
+	case ed25519.PublicKey:
+		return Ed25519
+	case *rsa.PublicKey:
+		return RSA
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+
+	// keys matches valid label keys (e.g., env or example.com/team).
+	keys = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+	// values matches valid label values (which may be empty).
+	values = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+)
+
+// Parse returns the labels for a list of key=value pairs (e.g., env=staging).
+func Parse(pairs []string) (map[string]string, error) {
+
+	labels := map[string]string{}
+
+	for _, pair := range pairs {
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("error parsing label [%s] must be in the format key=value", pair)
+		}
+
+		err := Validate(parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		labels[parts[0]] = parts[1]
+	}
+
+	return labels, nil
+}
+
+// Validate returns an error if a label key or value contains characters other than letters, digits, dots, dashes and
+// underscores (keys may also contain slashes) or does not begin and end with a letter or digit.
+func Validate(key string, value string) error {
+
+	if !keys.MatchString(key) {
+		return fmt.Errorf("error parsing label key [%s] must be letters, digits, dots, dashes, underscores or slashes beginning and ending with a letter or digit", key)
+	}
+
+	if !values.MatchString(value) {
+		return fmt.Errorf("error parsing label value [%s] must be letters, digits, dots, dashes or underscores beginning and ending with a letter or digit", value)
+	}
+
+	return nil
+}
+
+// Matches returns true if the labels contain every key=value pair of a selector.
+func Matches(labels map[string]string, selector map[string]string) bool {
+
+	for key, value := range selector {
+		if actual, found := labels[key]; !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Format returns the labels as comma separated key=value pairs ordered by key.
+func Format(labels map[string]string) string {
+
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
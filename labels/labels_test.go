@@ -0,0 +1,74 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labels
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLabels(t *testing.T) {
+
+	Convey("Parse", t, func() {
+
+		Convey("when the pairs are valid", func() {
+
+			labels, err := Parse([]string{"env=staging", "example.com/team=mesh", "empty="})
+
+			Convey("it returns the labels", func() {
+				So(err, ShouldBeNil)
+				So(labels, ShouldResemble, map[string]string{"env": "staging", "example.com/team": "mesh", "empty": ""})
+			})
+		})
+
+		Convey("when a pair has no value", func() {
+
+			_, err := Parse([]string{"env"})
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when a key is invalid", func() {
+
+			_, err := Parse([]string{"-env=staging"})
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Matches", t, func() {
+
+		labels := map[string]string{"env": "staging", "team": "mesh"}
+
+		Convey("it returns true when every pair of the selector matches", func() {
+			So(Matches(labels, map[string]string{"env": "staging", "team": "mesh"}), ShouldBeTrue)
+			So(Matches(labels, map[string]string{}), ShouldBeTrue)
+		})
+
+		Convey("it returns false when a pair of the selector does not match", func() {
+			So(Matches(labels, map[string]string{"env": "production"}), ShouldBeFalse)
+			So(Matches(labels, map[string]string{"region": "east"}), ShouldBeFalse)
+		})
+	})
+
+	Convey("Format", t, func() {
+		So(Format(map[string]string{"team": "mesh", "env": "staging"}), ShouldEqual, "env=staging,team=mesh")
+	})
+}
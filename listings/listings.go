@@ -0,0 +1,384 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/labels"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// CustomColumns prefixes outputs that define their own columns (e.g., custom-columns=NAME:commonName,EXPIRES:expiration).
+const CustomColumns = "custom-columns="
+
+// columns defines the values of the entry fields that may be used as custom columns.
+var columns = map[string]func(entry *Entry) string{
+	"alias":              func(entry *Entry) string { return entry.Alias },
+	"authority":          func(entry *Entry) string { return entry.Authority },
+	"commonName":         func(entry *Entry) string { return entry.CommonName },
+	"created":            func(entry *Entry) string { return entry.Created.Format(time.RFC3339) },
+	"expiration":         func(entry *Entry) string { return entry.Expiration.Format(time.RFC3339) },
+	"fingerprint":        func(entry *Entry) string { return entry.Fingerprint },
+	"keyAlgorithm":       func(entry *Entry) string { return entry.KeyAlgorithm },
+	"labels":             func(entry *Entry) string { return labels.Format(entry.Labels) },
+	"root":               func(entry *Entry) string { return fmt.Sprintf("%t", entry.Root) },
+	"signatureAlgorithm": func(entry *Entry) string { return entry.SignatureAlgorithm },
+	"successor":          func(entry *Entry) string { return entry.Successor },
+	"type":               func(entry *Entry) string { return entry.Type },
+}
+
+// sorts defines the orderings of entries by the name of the field being sorted on.
+var sorts = map[string]func(a *Entry, b *Entry) bool{
+	"created":     func(a *Entry, b *Entry) bool { return a.Created.Before(b.Created) },
+	"expiration":  func(a *Entry, b *Entry) bool { return a.Expiration.Before(b.Expiration) },
+	"fingerprint": func(a *Entry, b *Entry) bool { return false },
+	"name":        func(a *Entry, b *Entry) bool { return a.CommonName < b.CommonName },
+}
+
+// Entry defines an identity in a listing.  The fields are always present in json and yaml output so that scripts may
+// rely on them.
+type Entry struct {
+
+	// Alias defines the alias of the identity (empty if none).
+	Alias string `json:"alias" yaml:"alias"`
+
+	// Authority defines the fingerprint of the issuing authority (empty for roots).
+	Authority string `json:"authority" yaml:"authority"`
+
+	// CommonName defines the common name of the identity.
+	CommonName string `json:"commonName" yaml:"commonName"`
+
+	// Created defines when the certificate of the identity became valid (i.e., its not before time).
+	Created time.Time `json:"created" yaml:"created"`
+
+	// Expiration defines when the certificate of the identity expires (i.e., its not after time).
+	Expiration time.Time `json:"expiration" yaml:"expiration"`
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// KeyAlgorithm defines the algorithm and size of the key of the identity (e.g., RSA-4096).
+	KeyAlgorithm string `json:"keyAlgorithm" yaml:"keyAlgorithm"`
+
+	// Labels defines the key=value labels of the identity.
+	Labels map[string]string `json:"labels" yaml:"labels"`
+
+	// Root defines whether the identity is a root authority.
+	Root bool `json:"root" yaml:"root"`
+
+	// SignatureAlgorithm defines the algorithm used to sign the certificate of the identity.
+	SignatureAlgorithm string `json:"signatureAlgorithm" yaml:"signatureAlgorithm"`
+
+	// Successor defines the fingerprint of the identity that renewed the identity (empty if not renewed).
+	Successor string `json:"successor" yaml:"successor"`
+
+	// Type defines the type of the identity (e.g., authority or leaf).
+	Type string `json:"type" yaml:"type"`
+}
+
+// Options defines the filters applied to a listing.
+type Options struct {
+
+	// Authority defines the fingerprint of the authority that must have issued the listed identities.
+	Authority string
+
+	// Expired defines whether to list only the expired identities.
+	Expired bool
+
+	// NameRegex defines the regular expression the common names of the listed identities must match.
+	NameRegex string
+
+	// Selector defines the key=value labels the listed identities must have.
+	Selector map[string]string
+}
+
+// List returns the entries for the identities in a store of the provided type (e.g., authority or leaf) ordered by
+// fingerprint.  The authority of each entry is the issuing authority in the provided authority store (see
+// stores.MatchIssuer) or empty if it is not stored.
+func List(store stores.IdentityStore, authorities stores.IdentityStore, identityType string) ([]*Entry, error) {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := authorities.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, len(records))
+	for index, record := range records {
+		entries[index] = entry(record, candidates, identityType)
+	}
+
+	err = Sort(entries, "fingerprint")
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Select returns the entries for the identities in a store of the provided type with the provided fingerprints in the
+// order of the fingerprints (see List).
+func Select(store stores.IdentityStore, authorities stores.IdentityStore, identityType string, fingerprints []string) ([]*Entry, error) {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := authorities.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*Entry{}
+
+	for _, fingerprint := range fingerprints {
+
+		record, err := stores.FindRecord(records, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry(record, candidates, identityType))
+	}
+
+	return entries, nil
+}
+
+// Filter returns the entries that match the provided options at the provided time.
+func Filter(entries []*Entry, options Options, now time.Time) ([]*Entry, error) {
+
+	var expression *regexp.Regexp
+
+	if options.NameRegex != "" {
+
+		compiled, err := regexp.Compile(options.NameRegex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing name regex [%s]", options.NameRegex)
+		}
+
+		expression = compiled
+	}
+
+	filtered := []*Entry{}
+
+	for _, entry := range entries {
+
+		if options.Authority != "" && entry.Authority != options.Authority {
+			continue
+		}
+
+		if options.Expired && !entry.Expiration.Before(now) {
+			continue
+		}
+
+		if expression != nil && !expression.MatchString(entry.CommonName) {
+			continue
+		}
+
+		if !labels.Matches(entry.Labels, options.Selector) {
+			continue
+		}
+
+		filtered = append(filtered, entry)
+	}
+
+	return filtered, nil
+}
+
+// Sort orders entries by the provided field (i.e., created, expiration, fingerprint or name) and then by fingerprint.
+func Sort(entries []*Entry, by string) error {
+
+	less, found := sorts[by]
+	if !found {
+		return fmt.Errorf("error parsing sort by [%s] must be one of [created, expiration, fingerprint, name]", by)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+
+		if less(entries[i], entries[j]) {
+			return true
+		}
+
+		if less(entries[j], entries[i]) {
+			return false
+		}
+
+		return entries[i].Fingerprint < entries[j].Fingerprint
+	})
+
+	return nil
+}
+
+// Format returns the entries in the provided output format (i.e., json, yaml, text, wide or custom-columns=...).
+func Format(entries []*Entry, output string) ([]byte, error) {
+
+	switch {
+	case output == "json":
+
+		bytes, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding entries as json")
+		}
+
+		return append(bytes, '\n'), nil
+
+	case output == "yaml":
+
+		bytes, err := yaml.Marshal(entries)
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding entries as yaml")
+		}
+
+		return bytes, nil
+
+	case output == "text":
+
+		var buffer bytes.Buffer
+
+		for _, entry := range entries {
+
+			if entry.Type == "leaf" {
+				fmt.Fprintf(&buffer, "%s\t%s\t%s\t%v\t%s\t%s\n", entry.Fingerprint, entry.Authority, entry.CommonName, entry.Expiration, entry.KeyAlgorithm, entry.SignatureAlgorithm)
+			} else {
+				fmt.Fprintf(&buffer, "%s\t%s\t%v\t%s\t%s\n", entry.Fingerprint, entry.CommonName, entry.Expiration, entry.KeyAlgorithm, entry.SignatureAlgorithm)
+			}
+		}
+
+		return buffer.Bytes(), nil
+
+	case output == "wide":
+
+		return table(entries, []string{"FINGERPRINT", "ALIAS", "TYPE", "AUTHORITY", "NAME", "CREATED", "EXPIRATION", "KEY", "SIGNATURE", "LABELS"},
+			[]string{"fingerprint", "alias", "type", "authority", "commonName", "created", "expiration", "keyAlgorithm", "signatureAlgorithm", "labels"})
+
+	case strings.HasPrefix(output, CustomColumns):
+
+		headers := []string{}
+		fields := []string{}
+
+		for _, column := range strings.Split(strings.TrimPrefix(output, CustomColumns), ",") {
+
+			parts := strings.SplitN(column, ":", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("error parsing custom column [%s] must be in the format HEADER:field", column)
+			}
+
+			field := strings.TrimPrefix(parts[1], ".")
+			if _, found := columns[field]; !found && !strings.HasPrefix(field, "labels.") {
+				return nil, fmt.Errorf("error parsing custom column [%s] field must be one of [%s] or labels.KEY", column, strings.Join(fieldNames(), ", "))
+			}
+
+			headers = append(headers, parts[0])
+			fields = append(fields, field)
+		}
+
+		return table(entries, headers, fields)
+
+	default:
+		return nil, fmt.Errorf("error parsing output [%s] must be one of [json, yaml, text, wide, %sHEADER:field,...]", output, CustomColumns)
+	}
+}
+
+// fieldNames returns the sorted names of the fields that may be used as custom columns.
+func fieldNames() []string {
+
+	names := []string{}
+	for name := range columns {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// table returns the provided fields of the entries as aligned columns beneath the provided headers.  Fields of the form
+// labels.KEY return the value of the label with the key and empty values are shown as <none>.
+func table(entries []*Entry, headers []string, fields []string) ([]byte, error) {
+
+	var buffer bytes.Buffer
+
+	writer := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+
+	for _, entry := range entries {
+
+		values := []string{}
+
+		for _, field := range fields {
+
+			value := ""
+			if strings.HasPrefix(field, "labels.") {
+				value = entry.Labels[strings.TrimPrefix(field, "labels.")]
+			} else {
+				value = columns[field](entry)
+			}
+
+			if value == "" {
+				value = "<none>"
+			}
+
+			values = append(values, value)
+		}
+
+		fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+
+	writer.Flush()
+
+	return buffer.Bytes(), nil
+}
+
+// entry returns the entry of the provided type for a record issued by one of the candidate authorities.
+func entry(record *stores.Record, candidates []*stores.Record, identityType string) *Entry {
+
+	entry := &Entry{
+		Alias:              record.Metadata.Alias,
+		Authority:          stores.MatchIssuer(candidates, record.Certificate, record.Authorities, record.Metadata.Authority),
+		CommonName:         certificates.CommonName(record.Certificate),
+		Created:            record.Certificate.NotBefore,
+		Expiration:         certificates.Expiration(record.Certificate),
+		Fingerprint:        record.Fingerprint,
+		KeyAlgorithm:       certificates.KeyAlgorithm(record.Certificate),
+		Labels:             map[string]string{},
+		Root:               record.Metadata.Root,
+		SignatureAlgorithm: certificates.SignatureAlgorithm(record.Certificate),
+		Successor:          record.Metadata.Successor,
+		Type:               identityType,
+	}
+
+	for key, value := range record.Metadata.Labels {
+		entry.Labels[key] = value
+	}
+
+	return entry
+}
@@ -0,0 +1,209 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listings
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListings(t *testing.T) {
+
+	Convey("List", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		_, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		So(stores.Annotate(leaves, fingerprint, "example", map[string]string{"env": "staging"}), ShouldBeNil)
+
+		entries, err := List(leaves, authorities, "leaf")
+
+		Convey("it returns an entry with the metadata of each identity", func() {
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 1)
+			So(entries[0].Fingerprint, ShouldEqual, fingerprint)
+			So(entries[0].Authority, ShouldEqual, certificates.Fingerprint(authority.Certificate))
+			So(entries[0].Alias, ShouldEqual, "example")
+			So(entries[0].Labels, ShouldResemble, map[string]string{"env": "staging"})
+			So(entries[0].Type, ShouldEqual, "leaf")
+		})
+	})
+
+	Convey("Select", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		_, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		first, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		second, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		_, err = leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		Convey("it returns only the entries with the fingerprints in their order", func() {
+			entries, err := Select(leaves, authorities, "leaf", []string{second, first})
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 2)
+			So(entries[0].Fingerprint, ShouldEqual, second)
+			So(entries[1].Fingerprint, ShouldEqual, first)
+			So(entries[0].Authority, ShouldEqual, certificates.Fingerprint(authority.Certificate))
+		})
+
+		Convey("when a fingerprint is not stored it returns an error", func() {
+			_, err := Select(leaves, authorities, "leaf", []string{"e3b0c44298fc"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Filter", t, func() {
+
+		now := time.Now()
+
+		entries := []*Entry{
+			{Authority: "aaaaaaaaaaaa", CommonName: "api.example.com", Expiration: now.Add(-time.Hour), Fingerprint: "111111111111", Labels: map[string]string{"env": "prod"}},
+			{Authority: "bbbbbbbbbbbb", CommonName: "web.example.com", Expiration: now.Add(time.Hour), Fingerprint: "222222222222", Labels: map[string]string{"env": "staging"}},
+		}
+
+		Convey("when filtering by authority it returns the entries issued by the authority", func() {
+			filtered, err := Filter(entries, Options{Authority: "bbbbbbbbbbbb"}, now)
+			So(err, ShouldBeNil)
+			So(filtered, ShouldResemble, entries[1:])
+		})
+
+		Convey("when filtering expired entries it returns the entries that have expired", func() {
+			filtered, err := Filter(entries, Options{Expired: true}, now)
+			So(err, ShouldBeNil)
+			So(filtered, ShouldResemble, entries[:1])
+		})
+
+		Convey("when filtering by name regex it returns the entries with matching common names", func() {
+			filtered, err := Filter(entries, Options{NameRegex: "^web\\."}, now)
+			So(err, ShouldBeNil)
+			So(filtered, ShouldResemble, entries[1:])
+		})
+
+		Convey("when filtering by selector it returns the entries with the labels", func() {
+			filtered, err := Filter(entries, Options{Selector: map[string]string{"env": "prod"}}, now)
+			So(err, ShouldBeNil)
+			So(filtered, ShouldResemble, entries[:1])
+		})
+
+		Convey("when the name regex is invalid it returns an error", func() {
+			_, err := Filter(entries, Options{NameRegex: "("}, now)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Sort", t, func() {
+
+		now := time.Now()
+
+		entries := []*Entry{
+			{CommonName: "b", Created: now, Expiration: now.Add(time.Hour), Fingerprint: "333333333333"},
+			{CommonName: "a", Created: now.Add(time.Minute), Expiration: now.Add(2 * time.Hour), Fingerprint: "222222222222"},
+			{CommonName: "b", Created: now.Add(-time.Minute), Expiration: now.Add(time.Hour), Fingerprint: "111111111111"},
+		}
+
+		fingerprints := func() []string {
+			result := []string{}
+			for _, entry := range entries {
+				result = append(result, entry.Fingerprint)
+			}
+			return result
+		}
+
+		Convey("it orders the entries by the field and then by fingerprint", func() {
+
+			So(Sort(entries, "name"), ShouldBeNil)
+			So(fingerprints(), ShouldResemble, []string{"222222222222", "111111111111", "333333333333"})
+
+			So(Sort(entries, "expiration"), ShouldBeNil)
+			So(fingerprints(), ShouldResemble, []string{"111111111111", "333333333333", "222222222222"})
+
+			So(Sort(entries, "created"), ShouldBeNil)
+			So(fingerprints(), ShouldResemble, []string{"111111111111", "333333333333", "222222222222"})
+
+			So(Sort(entries, "fingerprint"), ShouldBeNil)
+			So(fingerprints(), ShouldResemble, []string{"111111111111", "222222222222", "333333333333"})
+		})
+
+		Convey("when the field is unknown it returns an error", func() {
+			So(Sort(entries, "serial"), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Format", t, func() {
+
+		entries := []*Entry{
+			{CommonName: "api.example.com", Fingerprint: "111111111111", Labels: map[string]string{"env": "prod"}, Type: "leaf"},
+		}
+
+		Convey("when the output is json it returns every field of the entries", func() {
+
+			bytes, err := Format(entries, "json")
+			So(err, ShouldBeNil)
+
+			decoded := []map[string]interface{}{}
+			So(json.Unmarshal(bytes, &decoded), ShouldBeNil)
+			So(decoded[0], ShouldContainKey, "alias")
+			So(decoded[0], ShouldContainKey, "successor")
+			So(decoded[0]["commonName"], ShouldEqual, "api.example.com")
+		})
+
+		Convey("when the output is yaml it returns the entries", func() {
+			bytes, err := Format(entries, "yaml")
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldContainSubstring, "commonName: api.example.com")
+		})
+
+		Convey("when the output is custom columns it returns the columns", func() {
+			bytes, err := Format(entries, "custom-columns=NAME:commonName,ENV:labels.env,ALIAS:.alias")
+			So(err, ShouldBeNil)
+			So(string(bytes), ShouldEqual, "NAME             ENV   ALIAS\napi.example.com  prod  <none>\n")
+		})
+
+		Convey("when a custom column is unknown it returns an error", func() {
+			_, err := Format(entries, "custom-columns=NAME:serial")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("when the output is unknown it returns an error", func() {
+			_, err := Format(entries, "xml")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs12
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/pkg/errors"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+// Encode returns a password protected PKCS #12 key store containing a private key, its certificate and the
+// certificates of its authorities.
+func Encode(key crypto.Signer, certificate *x509.Certificate, authorities []*x509.Certificate, password string) ([]byte, error) {
+
+	bytes, err := gopkcs12.Modern.Encode(key, certificate, authorities, password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error encoding key store for [%s]", certificate.Subject.CommonName)
+	}
+
+	return bytes, nil
+}
+
+// EncodeTrustStore returns a password protected PKCS #12 trust store containing certificates trusted for any purpose.
+// The friendly name is used as the alias of the certificates and is suffixed with an index when there are multiple
+// certificates.  Certificates are aliased by their common name if the friendly name is empty.
+func EncodeTrustStore(certificates []*x509.Certificate, password string, friendlyName string) ([]byte, error) {
+
+	entries := make([]gopkcs12.TrustStoreEntry, len(certificates))
+
+	for index, certificate := range certificates {
+
+		alias := friendlyName
+
+		switch {
+		case alias == "":
+			alias = certificate.Subject.CommonName
+		case len(certificates) > 1:
+			alias = fmt.Sprintf("%s-%d", friendlyName, index+1)
+		}
+
+		entries[index] = gopkcs12.TrustStoreEntry{Cert: certificate, FriendlyName: alias}
+	}
+
+	bytes, err := gopkcs12.Modern.EncodeTrustStoreEntries(entries, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding trust store")
+	}
+
+	return bytes, nil
+}
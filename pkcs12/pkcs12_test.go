@@ -0,0 +1,79 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs12
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+func TestPKCS12(t *testing.T) {
+
+	Convey("Encode", t, func() {
+
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+		leaf.Authorities = []*x509.Certificate{authority.Certificate}
+
+		bytes, err := Encode(leaf.Key, leaf.Certificate, leaf.Authorities, "password")
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it returns a key store decoded by an independent implementation", func() {
+
+			key, certificate, authorities, err := gopkcs12.DecodeChain(bytes, "password")
+
+			So(err, ShouldBeNil)
+			So(key, ShouldResemble, leaf.Key)
+			So(certificate.Equal(leaf.Certificate), ShouldBeTrue)
+			So(authorities, ShouldHaveLength, 1)
+			So(authorities[0].Equal(authority.Certificate), ShouldBeTrue)
+		})
+
+		Convey("it returns a key store that does not decode with another password", func() {
+			_, _, _, err := gopkcs12.DecodeChain(bytes, "other")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("EncodeTrustStore", t, func() {
+
+		first := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		second := tests.MustGenerateAuthority(t, keys.RSA, "")
+
+		bytes, err := EncodeTrustStore([]*x509.Certificate{first.Certificate, second.Certificate}, "password", "acert")
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it returns a trust store decoded by an independent implementation", func() {
+
+			certificates, err := gopkcs12.DecodeTrustStore(bytes, "password")
+
+			So(err, ShouldBeNil)
+			So(certificates, ShouldHaveLength, 2)
+			So(certificates[0].Equal(first.Certificate), ShouldBeTrue)
+			So(certificates[1].Equal(second.Certificate), ShouldBeTrue)
+		})
+	})
+}
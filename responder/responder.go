@@ -0,0 +1,295 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responder
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// maximumRequestSize defines the maximum size in bytes of an OCSP request.
+const maximumRequestSize = 10240
+
+// Responder answers OCSP requests (see RFC 6960) for the authorities of a store using the revocations recorded by
+// the authorities.  Responses are signed by the authority or by a delegated responder issued by the authority.
+type Responder struct {
+	authorities stores.IdentityStore
+	delegates   map[string]*identities.Identity
+	indexes     map[crypto.Hash]map[string]string
+	mutex       sync.Mutex
+	nextUpdate  time.Duration
+}
+
+// NewResponder returns a new responder for the authorities of a store.  The next update of each response is the
+// provided duration after the response is produced.
+func NewResponder(authorities stores.IdentityStore, nextUpdate time.Duration) *Responder {
+	return &Responder{
+		authorities: authorities,
+		delegates:   map[string]*identities.Identity{},
+		indexes:     map[crypto.Hash]map[string]string{},
+		nextUpdate:  nextUpdate,
+	}
+}
+
+// Delegate signs the responses for the authority that issued a leaf with the leaf.  The leaf must have a key and be
+// issued for OCSP signing.
+func (r *Responder) Delegate(leaf *identities.Identity) error {
+
+	fingerprint := certificates.Fingerprint(leaf.Certificate)
+
+	if leaf.Key == nil {
+		return fmt.Errorf("error delegating to responder [%s] no key defined", fingerprint)
+	}
+
+	if !signing(leaf.Certificate) {
+		return fmt.Errorf("error delegating to responder [%s] not issued for OCSP signing", fingerprint)
+	}
+
+	authorities, err := r.authorities.ListRecords()
+	if err != nil {
+		return errors.Wrapf(err, "error delegating to responder [%s]", fingerprint)
+	}
+
+	issuer := stores.MatchIssuer(authorities, leaf.Certificate, leaf.Authorities, "")
+	if issuer == "" {
+		return fmt.Errorf("error delegating to responder [%s] no stored authority issued the certificate", fingerprint)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.delegates[issuer] = leaf
+
+	return nil
+}
+
+// Respond returns the DER encoded response to a DER encoded OCSP request.  Requests that cannot be answered produce
+// the corresponding OCSP error response (e.g., unauthorized for certificates not issued by a stored authority).
+func (r *Responder) Respond(request []byte) ([]byte, error) {
+
+	parsed, err := ocsp.ParseRequest(request)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+
+	authority, err := r.issuer(parsed)
+	if err != nil {
+		return ocsp.InternalErrorErrorResponse, err
+	}
+
+	if authority == nil {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	fingerprint := certificates.Fingerprint(authority.Certificate)
+
+	metadata, err := r.authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return ocsp.InternalErrorErrorResponse, err
+	}
+
+	now := time.Now()
+
+	template := ocsp.Response{
+		IssuerHash:   parsed.HashAlgorithm,
+		NextUpdate:   now.Add(r.nextUpdate),
+		SerialNumber: parsed.SerialNumber,
+		Status:       ocsp.Unknown,
+		ThisUpdate:   now,
+	}
+
+	serial := serials.Format(parsed.SerialNumber)
+
+	if revocation, found := metadata.Revocations[serial]; found {
+		template.RevocationReason = revocation.Reason
+		template.RevokedAt = revocation.Time
+		template.Status = ocsp.Revoked
+	} else if _, found := metadata.Serials[serial]; found {
+		template.Status = ocsp.Good
+	}
+
+	signer := authority
+
+	r.mutex.Lock()
+	delegate, found := r.delegates[fingerprint]
+	r.mutex.Unlock()
+
+	if found {
+		signer = delegate
+		template.Certificate = delegate.Certificate
+	}
+
+	response, err := ocsp.CreateResponse(authority.Certificate, signer.Certificate, template, signer.Key)
+	if err != nil {
+		return ocsp.InternalErrorErrorResponse, errors.Wrapf(err, "error signing response for authority [%s]", fingerprint)
+	}
+
+	return response, nil
+}
+
+// ServeHTTP answers OCSP requests sent with the GET or POST methods (see RFC 6960 appendix A).
+func (r *Responder) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	var body []byte
+	var err error
+
+	switch request.Method {
+	case http.MethodGet:
+
+		var unescaped string
+
+		unescaped, err = url.PathUnescape(strings.TrimPrefix(request.URL.EscapedPath(), "/"))
+		if err == nil {
+			body, err = base64.StdEncoding.DecodeString(unescaped)
+		}
+
+	case http.MethodPost:
+		body, err = ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, maximumRequestSize))
+
+	default:
+		writer.Header().Set("Allow", "GET, POST")
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := ocsp.MalformedRequestErrorResponse
+
+	if err == nil {
+
+		response, err = r.Respond(body)
+		if err != nil {
+			log.Printf("error responding to request: %v", err)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/ocsp-response")
+	writer.Write(response)
+}
+
+// issuer returns the authority identified by a request or nil if the authority is not in the store.  The authorities
+// are indexed by the hashes of their names and keys the first time a hash algorithm is requested and re-indexed only
+// when a request matches no indexed authority that is still stored (e.g., an authority was added or deleted after the
+// index was built).
+func (r *Responder) issuer(request *ocsp.Request) (*identities.Identity, error) {
+
+	if !request.HashAlgorithm.Available() {
+		return nil, nil
+	}
+
+	key := string(request.IssuerNameHash) + string(request.IssuerKeyHash)
+
+	r.mutex.Lock()
+	fingerprint, found := r.indexes[request.HashAlgorithm][key]
+	r.mutex.Unlock()
+
+	if found {
+
+		authority, err := r.authorities.Fetch(fingerprint)
+		if err == nil {
+			return authority, nil
+		}
+	}
+
+	index, err := r.index(request.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	r.indexes[request.HashAlgorithm] = index
+	r.mutex.Unlock()
+
+	fingerprint, found = index[key]
+	if !found {
+		return nil, nil
+	}
+
+	return r.authorities.Fetch(fingerprint)
+}
+
+// index returns the fingerprints of the stored authorities keyed by the hashes of their names and keys.
+func (r *Responder) index(hash crypto.Hash) (map[string]string, error) {
+
+	records, err := r.authorities.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]string, len(records))
+
+	for _, record := range records {
+
+		nameHash, keyHash, err := hashes(record.Certificate, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		index[string(nameHash)+string(keyHash)] = record.Fingerprint
+	}
+
+	return index, nil
+}
+
+// hashes returns the hashes of the subject name and public key of a certificate used to identify an issuer.
+func hashes(certificate *x509.Certificate, hash crypto.Hash) ([]byte, []byte, error) {
+
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	_, err := asn1.Unmarshal(certificate.RawSubjectPublicKeyInfo, &info)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error parsing public key of [%s]", certificates.Fingerprint(certificate))
+	}
+
+	nameHash := hash.New()
+	nameHash.Write(certificate.RawSubject)
+
+	keyHash := hash.New()
+	keyHash.Write(info.PublicKey.RightAlign())
+
+	return nameHash.Sum(nil), keyHash.Sum(nil), nil
+}
+
+// signing returns true if a certificate is issued for OCSP signing.
+func signing(certificate *x509.Certificate) bool {
+
+	for _, usage := range certificate.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			return true
+		}
+	}
+
+	return false
+}
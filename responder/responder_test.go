@@ -0,0 +1,183 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responder
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestResponder(t *testing.T) {
+
+	Convey("Responder", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		good := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+		So(stores.Register(authorities, fingerprint, good.Certificate), ShouldBeNil)
+
+		revoked := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+		So(stores.Register(authorities, fingerprint, revoked.Certificate), ShouldBeNil)
+		So(stores.Revoke(authorities, fingerprint, revoked.Certificate, ocsp.KeyCompromise, time.Now()), ShouldBeNil)
+
+		responder := NewResponder(unlisted{authorities}, time.Hour)
+
+		server := httptest.NewServer(responder)
+		defer server.Close()
+
+		query := func(certificate *x509.Certificate, issuer *x509.Certificate) []byte {
+
+			request, err := ocsp.CreateRequest(certificate, issuer, nil)
+			So(err, ShouldBeNil)
+
+			response, err := http.Post(server.URL, "application/ocsp-request", bytes.NewReader(request))
+			So(err, ShouldBeNil)
+			defer response.Body.Close()
+
+			body, err := ioutil.ReadAll(response.Body)
+			So(err, ShouldBeNil)
+
+			return body
+		}
+
+		Convey("when the certificate is good", func() {
+
+			response, err := ocsp.ParseResponseForCert(query(good.Certificate, authority.Certificate), good.Certificate, authority.Certificate)
+
+			Convey("it returns a good response signed by the authority", func() {
+				So(err, ShouldBeNil)
+				So(response.Status, ShouldEqual, ocsp.Good)
+			})
+		})
+
+		Convey("when the certificate is revoked", func() {
+
+			response, err := ocsp.ParseResponseForCert(query(revoked.Certificate, authority.Certificate), revoked.Certificate, authority.Certificate)
+
+			Convey("it returns a revoked response with the reason", func() {
+				So(err, ShouldBeNil)
+				So(response.Status, ShouldEqual, ocsp.Revoked)
+				So(response.RevocationReason, ShouldEqual, ocsp.KeyCompromise)
+			})
+		})
+
+		Convey("when the certificate was not issued by the authority", func() {
+
+			unknown := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+			response, err := ocsp.ParseResponseForCert(query(unknown.Certificate, authority.Certificate), unknown.Certificate, authority.Certificate)
+
+			Convey("it returns an unknown response", func() {
+				So(err, ShouldBeNil)
+				So(response.Status, ShouldEqual, ocsp.Unknown)
+			})
+		})
+
+		Convey("when the issuer is not a stored authority", func() {
+
+			other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+			leaf := tests.MustGenerateLeaf(t, other, keys.ECDSA, "P256")
+
+			_, err := ocsp.ParseResponse(query(leaf.Certificate, other.Certificate), other.Certificate)
+
+			Convey("it returns an unauthorized response", func() {
+				So(err, ShouldResemble, ocsp.ResponseError{Status: ocsp.Unauthorized})
+			})
+		})
+
+		Convey("when the issuer is stored after the authorities were indexed", func() {
+
+			query(good.Certificate, authority.Certificate)
+
+			other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+			leaf := tests.MustGenerateLeaf(t, other, keys.ECDSA, "P256")
+
+			issuer, err := authorities.Upsert(other)
+			So(err, ShouldBeNil)
+			So(stores.Register(authorities, issuer, leaf.Certificate), ShouldBeNil)
+
+			response, err := ocsp.ParseResponseForCert(query(leaf.Certificate, other.Certificate), leaf.Certificate, other.Certificate)
+
+			Convey("it returns a response signed by the issuer", func() {
+				So(err, ShouldBeNil)
+				So(response.Status, ShouldEqual, ocsp.Good)
+			})
+		})
+
+		Convey("when a responder is delegated", func() {
+
+			key, err := keys.Generate(keys.ECDSA, 0, "P256")
+			So(err, ShouldBeNil)
+
+			delegate, err := authority.Issue(identities.Template{
+				ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+				KeyUsage:     x509.KeyUsageDigitalSignature,
+				NotAfter:     time.Now().Add(time.Hour),
+				NotBefore:    time.Now(),
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "responder"},
+			}, key)
+			So(err, ShouldBeNil)
+
+			So(responder.Delegate(delegate), ShouldBeNil)
+
+			response, err := ocsp.ParseResponseForCert(query(good.Certificate, authority.Certificate), good.Certificate, authority.Certificate)
+
+			Convey("it returns a response signed by the delegated responder", func() {
+				So(err, ShouldBeNil)
+				So(response.Status, ShouldEqual, ocsp.Good)
+				So(response.Certificate.Raw, ShouldResemble, delegate.Certificate.Raw)
+			})
+		})
+
+		Convey("when a leaf not issued for OCSP signing is delegated", func() {
+
+			Convey("it returns a non-nil error", func() {
+				So(responder.Delegate(good), ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+// unlisted defines an identity store that fails to list identities (and therefore to read every key) so that the
+// responder must locate authorities by their records.
+type unlisted struct {
+	stores.IdentityStore
+}
+
+// List returns an error.
+func (u unlisted) List() ([]*identities.Identity, error) {
+	return nil, fmt.Errorf("error listing identities not permitted")
+}
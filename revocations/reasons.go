@@ -0,0 +1,65 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocations
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reasons defines the names of the RFC 5280 (section 5.3.1) reason codes supported for revocation.
+var reasons = []struct {
+	code int
+	name string
+}{
+	{0, "unspecified"},
+	{1, "keyCompromise"},
+	{2, "caCompromise"},
+	{3, "affiliationChanged"},
+	{4, "superseded"},
+	{5, "cessationOfOperation"},
+	{6, "certificateHold"},
+	{9, "privilegeWithdrawn"},
+	{10, "aaCompromise"},
+}
+
+// Reason returns the RFC 5280 reason code for the provided name (e.g., keyCompromise).
+func Reason(name string) (int, error) {
+
+	names := make([]string, len(reasons))
+
+	for index, reason := range reasons {
+
+		if strings.EqualFold(reason.name, name) {
+			return reason.code, nil
+		}
+
+		names[index] = reason.name
+	}
+
+	return 0, fmt.Errorf("error parsing reason [%s] must be one of [%s]", name, strings.Join(names, ", "))
+}
+
+// ReasonName returns the name of an RFC 5280 reason code.
+func ReasonName(code int) string {
+
+	for _, reason := range reasons {
+		if reason.code == code {
+			return reason.name
+		}
+	}
+
+	return fmt.Sprintf("unknown (%d)", code)
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocations
+
+import (
+	"crypto/x509"
+	"sort"
+
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+)
+
+// Entries returns the revocation list entries for the revocations recorded by an authority ordered by serial number.
+func Entries(revocations map[string]*stores.Revocation) ([]x509.RevocationListEntry, error) {
+
+	entries := make([]x509.RevocationListEntry, 0, len(revocations))
+
+	for serial, revocation := range revocations {
+
+		number, err := serials.Parse(serial)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, x509.RevocationListEntry{
+			ReasonCode:     revocation.Reason,
+			RevocationTime: revocation.Time,
+			SerialNumber:   number,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].SerialNumber.Cmp(entries[j].SerialNumber) < 0
+	})
+
+	return entries, nil
+}
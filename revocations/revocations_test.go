@@ -0,0 +1,90 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocations
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRevocations(t *testing.T) {
+
+	Convey("Reason", t, func() {
+
+		Convey("when the name is known", func() {
+
+			code, err := Reason("KeyCompromise")
+
+			Convey("it returns the reason code", func() {
+				So(err, ShouldBeNil)
+				So(code, ShouldEqual, 1)
+				So(ReasonName(code), ShouldEqual, "keyCompromise")
+			})
+		})
+
+		Convey("when the name is unknown", func() {
+
+			_, err := Reason("removeFromCRL")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Entries", t, func() {
+
+		revoked := time.Now().UTC().Truncate(time.Second)
+
+		entries, err := Entries(map[string]*stores.Revocation{
+			"0B": {Fingerprint: "b", Reason: 4, Time: revoked},
+			"0A": {Fingerprint: "a", Reason: 1, Time: revoked},
+		})
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it returns the entries ordered by serial number", func() {
+			So(entries, ShouldHaveLength, 2)
+			So(entries[0].SerialNumber.Int64(), ShouldEqual, 0x0a)
+			So(entries[0].ReasonCode, ShouldEqual, 1)
+			So(entries[1].SerialNumber.Int64(), ShouldEqual, 0x0b)
+		})
+
+		Convey("when signed by an authority", func() {
+
+			authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+			list, err := authority.RevocationList(entries, big.NewInt(1), revoked, revoked.Add(time.Hour), 0)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns a revocation list signed by the authority", func() {
+				So(list.CheckSignatureFrom(authority.Certificate), ShouldBeNil)
+				So(list.RevokedCertificateEntries, ShouldHaveLength, 2)
+				So(list.RevokedCertificateEntries[0].ReasonCode, ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serials
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// bits defines the size in bits of generated serial numbers.
+const bits = 128
+
+// Format returns the hexadecimal representation of a serial number padded to whole bytes (i.e., as printed by
+// OpenSSL).
+func Format(serial *big.Int) string {
+
+	formatted := strings.ToUpper(serial.Text(16))
+	if len(formatted)%2 == 1 {
+		formatted = "0" + formatted
+	}
+
+	return formatted
+}
+
+// Generate returns a cryptographically random positive serial number (see RFC 5280 section 4.1.2.2).
+func Generate() (*big.Int, error) {
+
+	for {
+
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), bits))
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating serial number")
+		}
+
+		if serial.Sign() > 0 {
+			return serial, nil
+		}
+	}
+}
+
+// Parse returns the serial number for a hexadecimal representation optionally separated by colons (e.g., 0A:1B).
+func Parse(value string) (*big.Int, error) {
+
+	serial, ok := new(big.Int).SetString(strings.Replace(value, ":", "", -1), 16)
+	if !ok || serial.Sign() <= 0 {
+		return nil, fmt.Errorf("error parsing serial number [%s] must be a positive hexadecimal number", value)
+	}
+
+	return serial, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serials
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSerials(t *testing.T) {
+
+	Convey("Generate", t, func() {
+
+		first, err := Generate()
+		So(err, ShouldBeNil)
+
+		second, err := Generate()
+		So(err, ShouldBeNil)
+
+		Convey("it returns positive serial numbers of at most 128 bits", func() {
+			So(first.Sign(), ShouldEqual, 1)
+			So(first.BitLen(), ShouldBeLessThanOrEqualTo, 128)
+		})
+
+		Convey("it returns distinct serial numbers", func() {
+			So(first.Cmp(second), ShouldNotEqual, 0)
+		})
+	})
+
+	Convey("Parse", t, func() {
+
+		Convey("when the value is formatted", func() {
+
+			serial, err := Generate()
+			So(err, ShouldBeNil)
+
+			parsed, err := Parse(Format(serial))
+
+			Convey("it returns the serial number", func() {
+				So(err, ShouldBeNil)
+				So(parsed.Cmp(serial), ShouldEqual, 0)
+			})
+		})
+
+		Convey("when the value is separated by colons", func() {
+
+			parsed, err := Parse("0a:1B")
+
+			Convey("it returns the serial number", func() {
+				So(err, ShouldBeNil)
+				So(parsed.Int64(), ShouldEqual, 0x0a1b)
+			})
+		})
+
+		Convey("when the value is not hexadecimal", func() {
+
+			_, err := Parse("xyz")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+
+	// aliases matches valid aliases.
+	aliases = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+	// hexadecimal matches values that could be fingerprints or prefixes of fingerprints.
+	hexadecimal = regexp.MustCompile(`^[A-Fa-f0-9]+$`)
+)
+
+// ValidateAlias returns an error if an alias contains characters other than letters, digits, dots, dashes and
+// underscores, does not begin with a letter or digit or is hexadecimal (and therefore indistinguishable from a
+// fingerprint).
+func ValidateAlias(alias string) error {
+
+	if !aliases.MatchString(alias) {
+		return fmt.Errorf("error parsing alias [%s] must be letters, digits, dots, dashes or underscores beginning with a letter or digit", alias)
+	}
+
+	if hexadecimal.MatchString(alias) {
+		return fmt.Errorf("error parsing alias [%s] must not be hexadecimal to avoid confusion with fingerprints", alias)
+	}
+
+	return nil
+}
+
+// AvailableAlias returns an error if an alias is invalid or used by an identity in the store other than the identity
+// with the provided fingerprint (empty if the identity has not been stored).  Only the records of the store are read.
+func AvailableAlias(store IdentityStore, fingerprint string, alias string) error {
+
+	err := ValidateAlias(alias)
+	if err != nil {
+		return err
+	}
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if record.Fingerprint != fingerprint && record.Metadata.Alias == alias {
+			return fmt.Errorf("error setting alias [%s] already used by [%s]", alias, record.Fingerprint)
+		}
+	}
+
+	return nil
+}
+
+// Annotate sets the alias (unless empty) of the identity with the provided fingerprint and adds the provided labels.
+func Annotate(store IdentityStore, fingerprint string, alias string, labels map[string]string) error {
+
+	if alias != "" {
+
+		err := SetAlias(store, fingerprint, alias)
+		if err != nil {
+			return err
+		}
+	}
+
+	return SetLabels(store, fingerprint, labels, nil)
+}
+
+// SetAlias sets the alias of the identity with the provided fingerprint after verifying that no other identity in the
+// store has the alias.  An empty alias removes the alias of the identity.
+func SetAlias(store IdentityStore, fingerprint string, alias string) error {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return err
+	}
+
+	record, err := FindRecord(records, fingerprint)
+	if err != nil {
+		return err
+	}
+
+	fingerprint = record.Fingerprint
+
+	if alias != "" {
+
+		err = AvailableAlias(store, fingerprint, alias)
+		if err != nil {
+			return err
+		}
+	}
+
+	metadata, err := store.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	metadata.Alias = alias
+
+	return store.UpdateMetadata(fingerprint, metadata)
+}
+
+// SetLabels adds (or replaces) labels of the identity with the provided fingerprint and removes the labels with the
+// provided keys.
+func SetLabels(store IdentityStore, fingerprint string, labels map[string]string, removed []string) error {
+
+	metadata, err := store.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	if metadata.Labels == nil {
+		metadata.Labels = map[string]string{}
+	}
+
+	for key, value := range labels {
+		metadata.Labels[key] = value
+	}
+
+	for _, key := range removed {
+		delete(metadata.Labels, key)
+	}
+
+	if len(metadata.Labels) == 0 {
+		metadata.Labels = nil
+	}
+
+	return store.UpdateMetadata(fingerprint, metadata)
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAliases(t *testing.T) {
+
+	Convey("SetAlias", t, func() {
+
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		err = stores.SetAlias(leaves, fingerprint, "web")
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it allows the identity to be fetched by the alias", func() {
+
+			identity, err := leaves.Fetch("web")
+
+			So(err, ShouldBeNil)
+			So(identity, ShouldNotBeNil)
+		})
+
+		Convey("when another identity is given the alias", func() {
+
+			other, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+
+			err = stores.SetAlias(leaves, other, "web")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the alias is hexadecimal", func() {
+
+			err := stores.SetAlias(leaves, fingerprint, "cafe")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the alias is removed", func() {
+
+			So(stores.SetAlias(leaves, "web", ""), ShouldBeNil)
+
+			_, err := leaves.Fetch("web")
+
+			Convey("it can no longer be used to fetch the identity", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("AvailableAlias", t, func() {
+
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		So(stores.SetAlias(leaves, fingerprint, "web"), ShouldBeNil)
+
+		Convey("when the alias is used by the identity it returns a nil error", func() {
+			So(stores.AvailableAlias(leaves, fingerprint, "web"), ShouldBeNil)
+		})
+
+		Convey("when the alias is used by another identity it returns a non-nil error", func() {
+			So(stores.AvailableAlias(leaves, "", "web"), ShouldNotBeNil)
+		})
+
+		Convey("when the alias is unused it returns a nil error", func() {
+			So(stores.AvailableAlias(leaves, "", "api"), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/serials"
+)
+
+// Dependent defines an authority or leaf issued (directly or through subordinate authorities) by an authority.
+type Dependent struct {
+
+	// Certificate defines the certificate of the identity.
+	Certificate *x509.Certificate
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string
+
+	// Issuer defines the fingerprint of the authority that issued the identity.
+	Issuer string
+
+	// Live defines whether the identity has neither expired nor been revoked by its issuer.
+	Live bool
+
+	// Type defines the type of the identity (i.e., authority or leaf).
+	Type string
+}
+
+// Dependents returns the authorities and leaves issued by the authority with the provided fingerprint (see
+// MatchIssuer) together with everything issued by those authorities ordered by fingerprint.  Only the certificates and
+// metadata of the stores are read.
+func Dependents(authorities IdentityStore, leaves IdentityStore, fingerprint string, now time.Time) ([]*Dependent, error) {
+
+	candidates, err := authorities.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	authority, err := FindRecord(candidates, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := leaves.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	revocations := map[string]map[string]*Revocation{}
+	for _, candidate := range candidates {
+		revocations[candidate.Fingerprint] = candidate.Metadata.Revocations
+	}
+
+	issued := map[string][]*Dependent{}
+
+	for _, tipe := range []struct {
+		name    string
+		records []*Record
+	}{{"authority", candidates}, {"leaf", records}} {
+
+		for _, record := range tipe.records {
+
+			// Self signed roots (including roots renewed with the key of another root) are not issued by another authority.
+			if identities.SelfSigned(record.Certificate) {
+				continue
+			}
+
+			issuer := MatchIssuer(candidates, record.Certificate, record.Authorities, record.Metadata.Authority)
+			if issuer == "" {
+				continue
+			}
+
+			_, revoked := revocations[issuer][serials.Format(record.Certificate.SerialNumber)]
+
+			issued[issuer] = append(issued[issuer], &Dependent{
+				Certificate: record.Certificate,
+				Fingerprint: record.Fingerprint,
+				Issuer:      issuer,
+				Live:        !revoked && now.Before(record.Certificate.NotAfter),
+				Type:        tipe.name,
+			})
+		}
+	}
+
+	dependents := []*Dependent{}
+	visited := map[string]bool{authority.Fingerprint: true}
+
+	for pending := []string{authority.Fingerprint}; len(pending) > 0; pending = pending[1:] {
+
+		for _, dependent := range issued[pending[0]] {
+
+			if visited[dependent.Fingerprint] {
+				continue
+			}
+
+			visited[dependent.Fingerprint] = true
+			dependents = append(dependents, dependent)
+
+			if dependent.Type == "authority" {
+				pending = append(pending, dependent.Fingerprint)
+			}
+		}
+	}
+
+	sort.Slice(dependents, func(i, j int) bool {
+		return dependents[i].Fingerprint < dependents[j].Fingerprint
+	})
+
+	return dependents, nil
+}
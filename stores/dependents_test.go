@@ -0,0 +1,113 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDependents(t *testing.T) {
+
+	Convey("Dependents", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		_, err = authorities.Upsert(other)
+		So(err, ShouldBeNil)
+
+		issued := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+		live, err := leaves.Upsert(issued)
+		So(err, ShouldBeNil)
+
+		_, err = leaves.Upsert(tests.MustGenerateLeaf(t, other, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		Convey("it returns the live leaves issued by the authority", func() {
+			dependents, err := stores.Dependents(authorities, leaves, fingerprint, time.Now())
+			So(err, ShouldBeNil)
+			So(dependents, ShouldHaveLength, 1)
+			So(dependents[0].Fingerprint, ShouldEqual, live)
+			So(dependents[0].Live, ShouldBeTrue)
+		})
+
+		Convey("when a leaf has been revoked it is not live", func() {
+
+			So(stores.Revoke(authorities, fingerprint, issued.Certificate, 0, time.Now()), ShouldBeNil)
+
+			dependents, err := stores.Dependents(authorities, leaves, fingerprint, time.Now())
+			So(err, ShouldBeNil)
+			So(dependents, ShouldHaveLength, 1)
+			So(dependents[0].Live, ShouldBeFalse)
+		})
+
+		Convey("when a leaf has expired it is not live", func() {
+			dependents, err := stores.Dependents(authorities, leaves, fingerprint, time.Now().Add(2*time.Hour))
+			So(err, ShouldBeNil)
+			So(dependents, ShouldHaveLength, 1)
+			So(dependents[0].Live, ShouldBeFalse)
+		})
+
+		Convey("when the authority issued a subordinate authority", func() {
+
+			intermediate := tests.MustGenerateIntermediate(t, authority, keys.ECDSA, "P256", time.Now())
+
+			subordinate, err := authorities.Upsert(intermediate)
+			So(err, ShouldBeNil)
+
+			nested, err := leaves.Upsert(tests.MustGenerateLeaf(t, intermediate, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+
+			dependents, err := stores.Dependents(authorities, leaves, fingerprint, time.Now())
+
+			Convey("it returns the subordinate authority and everything it issued", func() {
+
+				So(err, ShouldBeNil)
+
+				types := map[string]string{}
+				issuers := map[string]string{}
+				for _, dependent := range dependents {
+					types[dependent.Fingerprint] = dependent.Type
+					issuers[dependent.Fingerprint] = dependent.Issuer
+				}
+
+				So(types, ShouldResemble, map[string]string{live: "leaf", subordinate: "authority", nested: "leaf"})
+				So(issuers, ShouldResemble, map[string]string{live: fingerprint, subordinate: fingerprint, nested: subordinate})
+			})
+
+			Convey("the dependents of the subordinate authority do not include its issuer", func() {
+
+				dependents, err := stores.Dependents(authorities, leaves, subordinate, time.Now())
+
+				So(err, ShouldBeNil)
+				So(dependents, ShouldHaveLength, 1)
+				So(dependents[0].Fingerprint, ShouldEqual, nested)
+			})
+		})
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+
+	// check defines the plaintext encrypted into the check value of an encryption file.
+	check = "acert"
+)
+
+// Secret returns the passphrase or key file contents from which the encryption key of a store is derived.
+type Secret func() ([]byte, error)
+
+// encryption defines the on disk representation of the encryption settings of a store.
+type encryption struct {
+
+	// Algorithm defines the algorithm used to encrypt keys.
+	Algorithm string `json:"algorithm"`
+
+	// Check defines a value encrypted with the derived key used to verify secrets.
+	Check []byte `json:"check"`
+
+	// KDF defines the algorithm used to derive keys from secrets.
+	KDF string `json:"kdf"`
+
+	// Memory defines the memory in KiB used when deriving keys.
+	Memory uint32 `json:"memory"`
+
+	// Salt defines the salt used when deriving keys.
+	Salt []byte `json:"salt"`
+
+	// Threads defines the parallelism used when deriving keys.
+	Threads uint8 `json:"threads"`
+
+	// Time defines the number of passes used when deriving keys.
+	Time uint32 `json:"time"`
+}
+
+// newEncryption returns new encryption settings with a random salt for a secret.
+func newEncryption(secret []byte) (*encryption, cipher.AEAD, error) {
+
+	salt := make([]byte, 16)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error generating salt")
+	}
+
+	settings := &encryption{
+		Algorithm: "AES-256-GCM",
+		KDF:       "argon2id",
+		Memory:    64 * 1024,
+		Salt:      salt,
+		Threads:   4,
+		Time:      1,
+	}
+
+	aead, err := settings.aead(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings.Check, err = seal(aead, []byte(check), nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error encrypting check value")
+	}
+
+	return settings, aead, nil
+}
+
+// unlock returns the cipher for the key derived from a secret after verifying the secret against the check value.
+func (e *encryption) unlock(secret []byte) (cipher.AEAD, error) {
+
+	aead, err := e.aead(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := open(aead, e.Check, nil)
+	if err != nil || subtle.ConstantTimeCompare(plaintext, []byte(check)) != 1 {
+		return nil, errors.New("error unlocking store the passphrase or key file is incorrect")
+	}
+
+	return aead, nil
+}
+
+// aead returns the cipher for the key derived from a secret.
+func (e *encryption) aead(secret []byte) (cipher.AEAD, error) {
+
+	if e.Algorithm != "AES-256-GCM" || e.KDF != "argon2id" {
+		return nil, errors.Errorf("error unlocking store with unsupported algorithm [%s] or kdf [%s]", e.Algorithm, e.KDF)
+	}
+
+	block, err := aes.NewCipher(argon2.IDKey(secret, e.Salt, e.Time, e.Memory, e.Threads, 32))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating cipher")
+	}
+
+	return aead, nil
+}
+
+// readEncryption reads encryption settings from a file and returns nil if the file does not exist.
+func readEncryption(path string) (*encryption, error) {
+
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading encryption settings from [%s]", path)
+	}
+
+	var settings encryption
+
+	err = json.Unmarshal(bytes, &settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling encryption settings from [%s]", path)
+	}
+
+	return &settings, nil
+}
+
+// writeEncryption writes encryption settings to a file.
+func writeEncryption(path string, settings *encryption) error {
+
+	bytes, err := json.Marshal(settings)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling encryption settings to [%s]", path)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "error creating parent directory [%s]", filepath.Dir(path))
+	}
+
+	err = ioutil.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error writing encryption settings to [%s]", path)
+	}
+
+	return nil
+}
+
+// seal encrypts plaintext and returns the random nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, data []byte) ([]byte, error) {
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating nonce")
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts a nonce followed by ciphertext.
+func open(aead cipher.AEAD, sealed []byte, data []byte) ([]byte, error) {
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("error decrypting value that is too short")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decrypting value")
+	}
+
+	return plaintext, nil
+}
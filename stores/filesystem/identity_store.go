@@ -15,21 +15,38 @@
 package filesystem
 
 import (
+	"crypto"
+	"crypto/cipher"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/greymatter-io/acert/certificates"
 	"github.com/greymatter-io/acert/encoding"
-	"github.com/greymatter-io/nautls/identities"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/stores"
 	"github.com/pkg/errors"
 )
 
-// IdentityStore provides an on disk implementation of the IdentityStore interface.
+const (
+
+	// encryptionFile defines the name of the file containing the encryption settings of an encrypted store.
+	encryptionFile = ".encryption"
+
+	// scheme defines the URL scheme prefixing the values of an identity file.
+	scheme = "base64://"
+)
+
+// IdentityStore provides an on disk implementation of the IdentityStore and RequestStore interfaces.  The keys of the identities may
+// optionally be encrypted at rest with a key derived from a passphrase or key file (see Encrypt).
 type IdentityStore struct {
+	aead      cipher.AEAD
 	directory string
+	secret    Secret
 }
 
 // NewIdentityStore returns a new identity store instance.
@@ -39,30 +56,52 @@ func NewIdentityStore(directory string) *IdentityStore {
 	}
 }
 
-// Delete deletes the identity with the provided fingerprint from this store.
+// WithSecret sets the secret used to unlock this store when it is encrypted and returns this store.  Note that the
+// secret is only requested when an encrypted key is read or written.
+func (s *IdentityStore) WithSecret(secret Secret) *IdentityStore {
+	s.secret = secret
+	return s
+}
+
+// Delete deletes the identity with the provided fingerprint from this store by moving it to the trash (see Trash).
 func (s *IdentityStore) Delete(fingerprint string) error {
+	return s.Trash(fingerprint, "deleted")
+}
 
-	file := filepath.Join(s.directory, fmt.Sprintf("%s.json", fingerprint))
+// Fetch returns the identity with the provided fingerprint from this store.
+func (s *IdentityStore) Fetch(fingerprint string) (*identities.Identity, error) {
 
-	err := os.Remove(file)
+	file, err := s.path(fingerprint)
 	if err != nil {
-		return errors.Wrapf(err, "error deleting identity from [%s]", file)
+		return nil, err
 	}
 
-	return nil
+	identity, err := s.readIdentity(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading identity from [%s]", file)
+	}
+
+	return identity, nil
 }
 
-// Fetch returns the identity with the provided fingerprint from this store.
-func (s *IdentityStore) Fetch(fingerprint string) (*identities.Identity, error) {
+// FetchMetadata returns the metadata for the identity with the provided fingerprint from this store.
+func (s *IdentityStore) FetchMetadata(fingerprint string) (*stores.Metadata, error) {
 
-	file := filepath.Join(s.directory, fmt.Sprintf("%s.json", fingerprint))
+	file, err := s.path(fingerprint)
+	if err != nil {
+		return nil, err
+	}
 
-	identity, err := readIdentity(file)
+	record, err := readRecord(file)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error loading identity from [%s]", file)
+		return nil, errors.Wrapf(err, "error loading metadata from [%s]", file)
 	}
 
-	return identity, nil
+	if record.Metadata == nil {
+		return &stores.Metadata{}, nil
+	}
+
+	return record.Metadata, nil
 }
 
 // List returns an array of identities from this store.
@@ -77,7 +116,7 @@ func (s *IdentityStore) List() ([]*identities.Identity, error) {
 
 	for index, file := range files {
 
-		identity, err := readIdentity(file)
+		identity, err := s.readIdentity(file)
 		if err != nil {
 			return nil, errors.Wrapf(err, "error loading identity from [%s]", file)
 		}
@@ -88,12 +127,65 @@ func (s *IdentityStore) List() ([]*identities.Identity, error) {
 	return identities, nil
 }
 
+// ListRecords returns the records of the identities from this store without reading their keys.
+func (s *IdentityStore) ListRecords() ([]*stores.Record, error) {
+
+	files, err := filepath.Glob(filepath.Join(s.directory, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading contents of [%s]", s.directory)
+	}
+
+	records := make([]*stores.Record, len(files))
+
+	for index, file := range files {
+
+		record, err := readRecord(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading record from [%s]", file)
+		}
+
+		authorities, certificate, err := record.certificates(file)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata := record.Metadata
+		if metadata == nil {
+			metadata = &stores.Metadata{}
+		}
+
+		records[index] = &stores.Record{
+			Authorities: authorities,
+			Certificate: certificate,
+			Fingerprint: strings.TrimSuffix(filepath.Base(file), ".json"),
+			Metadata:    metadata,
+		}
+	}
+
+	return records, nil
+}
+
 // Upsert inserts or updates an identity into this store and returns the fingerprint.
 func (s *IdentityStore) Upsert(identity *identities.Identity) (string, error) {
 
 	fingerprint := certificates.Fingerprint(identity.Certificate)
 
-	err := writeIdentity(filepath.Join(s.directory, fmt.Sprintf("%s.json", fingerprint)), identity)
+	aead, err := s.unlock()
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(s.directory, fmt.Sprintf("%s.json", fingerprint))
+
+	// Fingerprints are truncated so a different certificate could share the file of a stored identity.
+	existing, err := readCertificate(file)
+	if err == nil && !existing.Equal(identity.Certificate) {
+		return "", fmt.Errorf("error upserting identity [%s] fingerprint collides with stored identity [%s]", certificates.FullFingerprint(identity.Certificate), certificates.FullFingerprint(existing))
+	} else if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return "", err
+	}
+
+	err = writeIdentity(file, identity, aead)
 	if err != nil {
 		return "", errors.Wrap(err, "error writing identity")
 	}
@@ -101,39 +193,407 @@ func (s *IdentityStore) Upsert(identity *identities.Identity) (string, error) {
 	return fingerprint, nil
 }
 
-// readIdentity reads an identity from a file.
-func readIdentity(path string) (*identities.Identity, error) {
+// UpdateMetadata replaces the metadata for the identity with the provided fingerprint in this store.
+func (s *IdentityStore) UpdateMetadata(fingerprint string, metadata *stores.Metadata) error {
 
-	bytes, err := ioutil.ReadFile(path)
+	file, err := s.path(fingerprint)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error reading identity from [%s]", path)
+		return err
 	}
 
-	var config identities.IdentityConfig
+	record, err := readRecord(file)
+	if err != nil {
+		return errors.Wrapf(err, "error loading metadata from [%s]", file)
+	}
 
-	err = json.Unmarshal(bytes, &config)
+	record.Metadata = metadata
+
+	err = writeRecord(file, record)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error unmarshalling identity from [%s]", path)
+		return errors.Wrapf(err, "error writing metadata to [%s]", file)
+	}
+
+	return nil
+}
+
+// Encrypted returns true if the keys of the identities in this store are encrypted.
+func (s *IdentityStore) Encrypted() (bool, error) {
+
+	settings, err := readEncryption(filepath.Join(s.directory, encryptionFile))
+	if err != nil {
+		return false, err
 	}
 
-	identity, err := config.Build()
+	return settings != nil, nil
+}
+
+// Encrypt encrypts the keys of the identities in this store with a key derived from a secret.  Keys of identities
+// subsequently written to this store are encrypted as well.  Encrypting an encrypted store with the same secret
+// encrypts any keys that remain unencrypted (e.g., following an interrupted migration).
+func (s *IdentityStore) Encrypt(secret []byte) error {
+
+	path := filepath.Join(s.directory, encryptionFile)
+
+	settings, err := readEncryption(path)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building identity from [%s]", path)
+		return err
 	}
 
-	return identity, nil
+	var aead cipher.AEAD
+
+	if settings == nil {
+
+		settings, aead, err = newEncryption(secret)
+		if err != nil {
+			return errors.Wrapf(err, "error encrypting store [%s]", s.directory)
+		}
+
+		err = writeEncryption(path, settings)
+		if err != nil {
+			return errors.Wrapf(err, "error encrypting store [%s]", s.directory)
+		}
+
+	} else {
+
+		aead, err = settings.unlock(secret)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.aead = aead
+
+	return s.rewrite(aead)
+}
+
+// Decrypt decrypts the keys of the identities in this store and stops encrypting the keys of identities subsequently
+// written to this store.
+func (s *IdentityStore) Decrypt() error {
+
+	aead, err := s.unlock()
+	if err != nil {
+		return err
+	}
+
+	if aead == nil {
+		return nil
+	}
+
+	err = s.rewrite(nil)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(s.directory, encryptionFile))
+	if err != nil {
+		return errors.Wrapf(err, "error decrypting store [%s]", s.directory)
+	}
+
+	s.aead = nil
+
+	return nil
+}
+
+// rewrite reads and writes every identity (including those in the trash) and request in this store encrypting keys
+// with a cipher if it is non-nil.
+func (s *IdentityStore) rewrite(aead cipher.AEAD) error {
+
+	files, err := filepath.Glob(filepath.Join(s.directory, "*.json"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading contents of [%s]", s.directory)
+	}
+
+	trashed, err := filepath.Glob(filepath.Join(s.directory, trashDirectory, "*.json"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading contents of [%s]", filepath.Join(s.directory, trashDirectory))
+	}
+
+	files = append(files, trashed...)
+
+	for _, file := range files {
+
+		identity, err := s.readIdentity(file)
+		if err != nil {
+			return errors.Wrapf(err, "error loading identity from [%s]", file)
+		}
+
+		err = writeIdentity(file, identity, aead)
+		if err != nil {
+			return errors.Wrapf(err, "error writing identity to [%s]", file)
+		}
+	}
+
+	return s.rewriteRequests(aead)
 }
 
-// writeIdentity writes an identity to a file.
-func writeIdentity(path string, identity *identities.Identity) error {
+// unlock returns the cipher used to encrypt the keys of this store or nil if this store is not encrypted.
+func (s *IdentityStore) unlock() (cipher.AEAD, error) {
+
+	if s.aead != nil {
+		return s.aead, nil
+	}
+
+	settings, err := readEncryption(filepath.Join(s.directory, encryptionFile))
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		return nil, nil
+	}
 
-	config := &identities.IdentityConfig{
-		Authorities: fmt.Sprintf("base64:///%s", encoding.ConfigEncodeCertificates(identity.Authorities)),
-		Certificate: fmt.Sprintf("base64:///%s", encoding.ConfigEncodeCertificate(identity.Certificate)),
-		Key:         fmt.Sprintf("base64:///%s", encoding.ConfigEncodeKey(identity.Key)),
+	if s.secret == nil {
+		return nil, fmt.Errorf("error unlocking store [%s] no passphrase or key file provided", s.directory)
 	}
 
-	bytes, err := json.Marshal(config)
+	secret, err := s.secret()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unlocking store [%s]", s.directory)
+	}
+
+	aead, err := settings.unlock(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	s.aead = aead
+
+	return aead, nil
+}
+
+// path returns the path of the file containing the identity identified by a fingerprint, a unique prefix of one, a full
+// SHA256 fingerprint or an alias.
+func (s *IdentityStore) path(fingerprint string) (string, error) {
+
+	stored, err := fingerprints(s.directory)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := stores.Resolve(fingerprint, stored, func(candidate string) (string, error) {
+
+		certificate, err := readCertificate(filepath.Join(s.directory, fmt.Sprintf("%s.json", candidate)))
+		if err != nil {
+			return "", err
+		}
+
+		return certificates.FullFingerprint(certificate), nil
+	}, func() (map[string]string, error) {
+
+		aliases := map[string]string{}
+
+		for _, candidate := range stored {
+
+			record, err := readRecord(filepath.Join(s.directory, fmt.Sprintf("%s.json", candidate)))
+			if err != nil {
+				return nil, err
+			}
+
+			if record.Metadata != nil && record.Metadata.Alias != "" {
+				aliases[record.Metadata.Alias] = candidate
+			}
+		}
+
+		return aliases, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.directory, fmt.Sprintf("%s.json", resolved)), nil
+}
+
+// fingerprints returns the fingerprints of the records in a directory.
+func fingerprints(directory string) ([]string, error) {
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading contents of [%s]", directory)
+	}
+
+	result := make([]string, len(files))
+	for index, file := range files {
+		result[index] = strings.TrimSuffix(filepath.Base(file), ".json")
+	}
+
+	return result, nil
+}
+
+// record defines the on disk representation of an identity.
+type record struct {
+	Authorities  string           `json:"authorities"`
+	Certificate  string           `json:"certificate"`
+	EncryptedKey []byte           `json:"encryptedKey,omitempty"`
+	Key          string           `json:"key,omitempty"`
+	Metadata     *stores.Metadata `json:"metadata,omitempty"`
+	Trashed      *trashing        `json:"trashed,omitempty"`
+}
+
+// readIdentity reads an identity from a file decrypting its key if necessary.
+func (s *IdentityStore) readIdentity(path string) (*identities.Identity, error) {
+
+	record, err := readRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	authorities, certificate, err := record.certificates(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.readKey(path, record.Key, record.EncryptedKey, record.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return identities.NewIdentity(authorities, certificate, key), nil
+}
+
+// certificates returns the decoded authorities and certificate of a record read from a file.
+func (r *record) certificates(path string) ([]*x509.Certificate, *x509.Certificate, error) {
+
+	authorities, err := encoding.ConfigDecodeCertificates(strings.TrimPrefix(r.Authorities, scheme))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error decoding authorities from [%s]", path)
+	}
+
+	certificate, err := encoding.ConfigDecodeCertificate(strings.TrimPrefix(r.Certificate, scheme))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error decoding certificate from [%s]", path)
+	}
+
+	return authorities, certificate, nil
+}
+
+// readCertificate reads the certificate of an identity from a file without reading its key.
+func readCertificate(path string) (*x509.Certificate, error) {
+
+	record, err := readRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := encoding.ConfigDecodeCertificate(strings.TrimPrefix(record.Certificate, scheme))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding certificate from [%s]", path)
+	}
+
+	return certificate, nil
+}
+
+// writeIdentity writes an identity to a file encrypting its key (if any) with a cipher if it is non-nil.  The metadata
+// (and trash details) of an existing identity in the file are preserved.
+func writeIdentity(path string, identity *identities.Identity, aead cipher.AEAD) error {
+
+	record := &record{
+		Authorities: fmt.Sprintf("%s/%s", scheme, encoding.ConfigEncodeCertificates(identity.Authorities)),
+		Certificate: fmt.Sprintf("%s/%s", scheme, encoding.ConfigEncodeCertificate(identity.Certificate)),
+	}
+
+	var err error
+
+	record.Key, record.EncryptedKey, err = writeKey(path, identity.Key, aead, record.Certificate)
+	if err != nil {
+		return err
+	}
+
+	existing, err := readRecord(path)
+	if err == nil {
+		record.Metadata = existing.Metadata
+		record.Trashed = existing.Trashed
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return err
+	}
+
+	return writeRecord(path, record)
+}
+
+// readKey returns the key of a record decrypting it if necessary or nil if the record has no key (e.g., the key of an
+// identity issued for a certificate request is held by the requester).  The additional data authenticates the
+// encrypted key.
+func (s *IdentityStore) readKey(path string, key string, encrypted []byte, data string) (crypto.Signer, error) {
+
+	if encrypted != nil {
+
+		aead, err := s.unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if aead == nil {
+			return nil, fmt.Errorf("error decrypting key from [%s] store is not encrypted", path)
+		}
+
+		plaintext, err := open(aead, encrypted, []byte(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decrypting key from [%s]", path)
+		}
+
+		key = string(plaintext)
+	}
+
+	if key == "" {
+		return nil, nil
+	}
+
+	signer, err := encoding.ConfigDecodeKey(strings.TrimPrefix(key, scheme))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding key from [%s]", path)
+	}
+
+	return signer, nil
+}
+
+// writeKey returns the encoded key or, if the cipher is non-nil, the encrypted encoded key authenticated with the
+// additional data.  Empty values are returned for a nil key.
+func writeKey(path string, key crypto.Signer, aead cipher.AEAD, data string) (string, []byte, error) {
+
+	if key == nil {
+		return "", nil, nil
+	}
+
+	encoded, err := encoding.ConfigEncodeKey(key)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error encoding key for file [%s]", path)
+	}
+
+	encoded = fmt.Sprintf("%s/%s", scheme, encoded)
+
+	if aead == nil {
+		return encoded, nil, nil
+	}
+
+	encrypted, err := seal(aead, []byte(encoded), []byte(data))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error encrypting key for file [%s]", path)
+	}
+
+	return "", encrypted, nil
+}
+
+// readRecord reads a record from a file.
+func readRecord(path string) (*record, error) {
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading identity from [%s]", path)
+	}
+
+	var record record
+
+	err = json.Unmarshal(bytes, &record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling identity from [%s]", path)
+	}
+
+	return &record, nil
+}
+
+// writeRecord writes a record to a file.
+func writeRecord(path string, record *record) error {
+
+	bytes, err := json.Marshal(record)
 	if err != nil {
 		return errors.Wrapf(err, "error marshalling identity to file [%s]", path)
 	}
@@ -16,8 +16,14 @@ package filesystem
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -54,6 +60,19 @@ func TestIdentityStore(t *testing.T) {
 					So(identity, ShouldNotBeNil)
 				})
 			})
+
+			Convey("when the fingerprint is a prefix of the identity", func() {
+
+				identity, err := store.Fetch("6556cb")
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the identity", func() {
+					So(identity, ShouldNotBeNil)
+				})
+			})
 		})
 
 		Convey(".List", func() {
@@ -91,5 +110,244 @@ func TestIdentityStore(t *testing.T) {
 				})
 			})
 		})
+
+		Convey(".Upsert", func() {
+
+			directory, err := ioutil.TempDir("", "upsert")
+			if err != nil {
+				t.Fail()
+			}
+
+			store := NewIdentityStore(directory)
+
+			Convey("when an ed25519 authority issues an ed25519 leaf", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.Ed25519, "")
+				leaf := tests.MustGenerateLeaf(t, authority, keys.Ed25519, "")
+
+				fingerprint, err := store.Upsert(leaf)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it stores an identity that can be fetched", func() {
+
+					fetched, err := store.Fetch(fingerprint)
+
+					So(err, ShouldBeNil)
+					So(fetched.Certificate.Raw, ShouldResemble, leaf.Certificate.Raw)
+					So(fetched.Key, ShouldResemble, leaf.Key)
+				})
+			})
+
+			Convey("when an rsa authority issues an ecdsa leaf", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.RSA, "")
+				leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+				fingerprint, err := store.Upsert(leaf)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it stores an identity that can be fetched", func() {
+
+					fetched, err := store.Fetch(fingerprint)
+
+					So(err, ShouldBeNil)
+					So(fetched.Certificate.Raw, ShouldResemble, leaf.Certificate.Raw)
+					So(fetched.Authorities[0].Raw, ShouldResemble, authority.Certificate.Raw)
+					So(fetched.Key, ShouldResemble, leaf.Key)
+				})
+			})
+
+			Convey("when the fingerprint collides with a stored identity", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+				other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+				fingerprint, err := store.Upsert(authority)
+				So(err, ShouldBeNil)
+
+				bytes, err := ioutil.ReadFile(filepath.Join(directory, fingerprint+".json"))
+				So(err, ShouldBeNil)
+				So(ioutil.WriteFile(filepath.Join(directory, certificates.Fingerprint(other.Certificate)+".json"), bytes, 0600), ShouldBeNil)
+
+				_, err = store.Upsert(other)
+
+				Convey("it returns a non-nil error", func() {
+					So(err, ShouldNotBeNil)
+				})
+			})
+
+			Convey("when an authority signs a public key", func() {
+
+				authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+				requester := tests.MustGenerateLeaf(t, authority, keys.RSA, "")
+
+				leaf, err := authority.Sign(identities.Template{
+					NotAfter:     requester.Certificate.NotAfter,
+					NotBefore:    requester.Certificate.NotBefore,
+					SerialNumber: requester.Certificate.SerialNumber,
+					Subject:      requester.Certificate.Subject,
+				}, requester.Key.Public())
+				if err != nil {
+					t.Fail()
+				}
+
+				fingerprint, err := store.Upsert(leaf)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it stores an identity without a key that can be fetched", func() {
+
+					fetched, err := store.Fetch(fingerprint)
+
+					So(err, ShouldBeNil)
+					So(fetched.Certificate.Raw, ShouldResemble, leaf.Certificate.Raw)
+					So(fetched.Key, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey(".UpdateMetadata", func() {
+
+			directory, err := ioutil.TempDir("", "metadata")
+			if err != nil {
+				t.Fail()
+			}
+
+			store := NewIdentityStore(directory)
+
+			authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+			fingerprint, err := store.Upsert(authority)
+			if err != nil {
+				t.Fail()
+			}
+
+			err = store.UpdateMetadata(fingerprint, &stores.Metadata{Root: true})
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it stores metadata that can be fetched", func() {
+
+				metadata, err := store.FetchMetadata(fingerprint)
+
+				So(err, ShouldBeNil)
+				So(metadata.Root, ShouldBeTrue)
+			})
+
+			Convey("when the identity is upserted again", func() {
+
+				_, err := store.Upsert(authority)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it preserves the metadata", func() {
+
+					metadata, err := store.FetchMetadata(fingerprint)
+
+					So(err, ShouldBeNil)
+					So(metadata.Root, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey(".Encrypt", func() {
+
+			directory, err := ioutil.TempDir("", "encrypt")
+			if err != nil {
+				t.Fail()
+			}
+
+			authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+			fingerprint, err := NewIdentityStore(directory).Upsert(authority)
+			if err != nil {
+				t.Fail()
+			}
+
+			err = NewIdentityStore(directory).Encrypt([]byte("passphrase"))
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it encrypts the keys of existing identities", func() {
+				bytes, err := ioutil.ReadFile(filepath.Join(directory, fingerprint+".json"))
+				So(err, ShouldBeNil)
+				So(string(bytes), ShouldContainSubstring, "encryptedKey")
+				So(string(bytes), ShouldNotContainSubstring, `"key"`)
+			})
+
+			Convey("when the store is read with the secret", func() {
+
+				store := NewIdentityStore(directory).WithSecret(func() ([]byte, error) { return []byte("passphrase"), nil })
+				identity, err := store.Fetch(fingerprint)
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the decrypted key", func() {
+					So(identity.Key, ShouldResemble, authority.Key)
+				})
+			})
+
+			Convey("when the records are listed without the secret", func() {
+
+				records, err := NewIdentityStore(directory).ListRecords()
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it returns the certificates without the keys", func() {
+					So(records, ShouldHaveLength, 1)
+					So(records[0].Fingerprint, ShouldEqual, fingerprint)
+					So(records[0].Certificate.Raw, ShouldResemble, authority.Certificate.Raw)
+					So(records[0].Metadata, ShouldNotBeNil)
+				})
+			})
+
+			Convey("when the store is read with an incorrect secret", func() {
+
+				store := NewIdentityStore(directory).WithSecret(func() ([]byte, error) { return []byte("incorrect"), nil })
+				identity, err := store.Fetch(fingerprint)
+
+				Convey("it returns a non-nil error", func() {
+					So(err, ShouldNotBeNil)
+				})
+
+				Convey("it returns a nil identity", func() {
+					So(identity, ShouldBeNil)
+				})
+			})
+
+			Convey("when the store is decrypted", func() {
+
+				store := NewIdentityStore(directory).WithSecret(func() ([]byte, error) { return []byte("passphrase"), nil })
+				err := store.Decrypt()
+
+				Convey("it returns a nil error", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("it can be read without the secret", func() {
+					identity, err := NewIdentityStore(directory).Fetch(fingerprint)
+					So(err, ShouldBeNil)
+					So(identity.Key, ShouldResemble, authority.Key)
+				})
+			})
+		})
 	})
 }
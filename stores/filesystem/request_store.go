@@ -0,0 +1,246 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/encoding"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+// requestsDirectory defines the name of the directory containing the pending requests of a store.
+const requestsDirectory = "requests"
+
+// DeleteRequest deletes the request with the provided fingerprint from this store.
+func (s *IdentityStore) DeleteRequest(fingerprint string) error {
+
+	file, err := s.requestPath(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(file)
+	if err != nil {
+		return errors.Wrapf(err, "error deleting request from [%s]", file)
+	}
+
+	return nil
+}
+
+// FetchRequest returns the request with the provided fingerprint from this store.
+func (s *IdentityStore) FetchRequest(fingerprint string) (*identities.Request, error) {
+
+	file, err := s.requestPath(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.readRequest(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading request from [%s]", file)
+	}
+
+	return request, nil
+}
+
+// ListRequests returns an array of requests from this store.
+func (s *IdentityStore) ListRequests() ([]*identities.Request, error) {
+
+	directory := filepath.Join(s.directory, requestsDirectory)
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading contents of [%s]", directory)
+	}
+
+	requests := make([]*identities.Request, len(files))
+
+	for index, file := range files {
+
+		request, err := s.readRequest(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading request from [%s]", file)
+		}
+
+		requests[index] = request
+	}
+
+	return requests, nil
+}
+
+// UpsertRequest inserts or updates a request into this store and returns the fingerprint.
+func (s *IdentityStore) UpsertRequest(request *identities.Request) (string, error) {
+
+	fingerprint := certificates.RequestFingerprint(request.CertificateRequest)
+
+	aead, err := s.unlock()
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(s.directory, requestsDirectory, fmt.Sprintf("%s.json", fingerprint))
+
+	// Fingerprints are truncated so a different request could share the file of a stored request.
+	existing, err := s.readRequest(file)
+	if err == nil && !bytes.Equal(existing.CertificateRequest.Raw, request.CertificateRequest.Raw) {
+		return "", fmt.Errorf("error upserting request [%s] fingerprint collides with stored request [%s]", certificates.FullRequestFingerprint(request.CertificateRequest), certificates.FullRequestFingerprint(existing.CertificateRequest))
+	} else if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return "", err
+	}
+
+	err = writeRequest(file, request, aead)
+	if err != nil {
+		return "", errors.Wrap(err, "error writing request")
+	}
+
+	return fingerprint, nil
+}
+
+// rewriteRequests reads and writes every request in this store encrypting keys with a cipher if it is non-nil.
+func (s *IdentityStore) rewriteRequests(aead cipher.AEAD) error {
+
+	directory := filepath.Join(s.directory, requestsDirectory)
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading contents of [%s]", directory)
+	}
+
+	for _, file := range files {
+
+		request, err := s.readRequest(file)
+		if err != nil {
+			return errors.Wrapf(err, "error loading request from [%s]", file)
+		}
+
+		err = writeRequest(file, request, aead)
+		if err != nil {
+			return errors.Wrapf(err, "error writing request to [%s]", file)
+		}
+	}
+
+	return nil
+}
+
+// requestPath returns the path of the file containing the request identified by a fingerprint, a unique prefix of one
+// or a full SHA256 fingerprint.
+func (s *IdentityStore) requestPath(fingerprint string) (string, error) {
+
+	directory := filepath.Join(s.directory, requestsDirectory)
+
+	stored, err := fingerprints(directory)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := stores.Resolve(fingerprint, stored, func(candidate string) (string, error) {
+
+		request, err := s.readRequest(filepath.Join(directory, fmt.Sprintf("%s.json", candidate)))
+		if err != nil {
+			return "", err
+		}
+
+		return certificates.FullRequestFingerprint(request.CertificateRequest), nil
+	}, func() (map[string]string, error) {
+		return map[string]string{}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(directory, fmt.Sprintf("%s.json", resolved)), nil
+}
+
+// requestRecord defines the on disk representation of a request.
+type requestRecord struct {
+	EncryptedKey []byte `json:"encryptedKey,omitempty"`
+	Key          string `json:"key,omitempty"`
+	Request      string `json:"request"`
+}
+
+// readRequest reads a request from a file decrypting its key if necessary.
+func (s *IdentityStore) readRequest(path string) (*identities.Request, error) {
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading request from [%s]", path)
+	}
+
+	var record requestRecord
+
+	err = json.Unmarshal(bytes, &record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling request from [%s]", path)
+	}
+
+	request, err := encoding.ConfigDecodeCertificateRequest(strings.TrimPrefix(record.Request, scheme))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding request from [%s]", path)
+	}
+
+	key, err := s.readKey(path, record.Key, record.EncryptedKey, record.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("error decoding key from [%s] no key defined", path)
+	}
+
+	return &identities.Request{CertificateRequest: request, Key: key}, nil
+}
+
+// writeRequest writes a request to a file encrypting its key with a cipher if it is non-nil.
+func writeRequest(path string, request *identities.Request, aead cipher.AEAD) error {
+
+	record := &requestRecord{
+		Request: fmt.Sprintf("%s/%s", scheme, encoding.ConfigEncodeCertificateRequest(request.CertificateRequest)),
+	}
+
+	var err error
+
+	record.Key, record.EncryptedKey, err = writeKey(path, request.Key, aead, record.Request)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling request to file [%s]", path)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "error creating parent directory [%s]", filepath.Dir(path))
+	}
+
+	err = ioutil.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error writing request to file [%s]", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+// trashDirectory defines the name of the directory containing the deleted identities of a store.
+const trashDirectory = "trash"
+
+// trashing defines when and why an identity was moved to the trash.
+type trashing struct {
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// trashed defines an identity in the trash and the file containing it.
+type trashed struct {
+	*stores.Trashed
+	file string
+}
+
+// ListTrash returns the identities in the trash of this store ordered by when they were deleted.
+func (s *IdentityStore) ListTrash() ([]*stores.Trashed, error) {
+
+	list, err := s.trashed()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*stores.Trashed, len(list))
+	for index, item := range list {
+		result[index] = item.Trashed
+	}
+
+	return result, nil
+}
+
+// Purge permanently deletes the identities moved to the trash of this store before the provided time and returns the
+// purged identities.
+func (s *IdentityStore) Purge(before time.Time) ([]*stores.Trashed, error) {
+
+	list, err := s.trashed()
+	if err != nil {
+		return nil, err
+	}
+
+	purged := []*stores.Trashed{}
+
+	for _, item := range list {
+
+		if !item.Deleted.Before(before) {
+			continue
+		}
+
+		err = os.Remove(item.file)
+		if err != nil {
+			return purged, errors.Wrapf(err, "error purging identity from [%s]", item.file)
+		}
+
+		purged = append(purged, item.Trashed)
+	}
+
+	return purged, nil
+}
+
+// Restore moves the most recently deleted identity with the provided fingerprint (or a unique prefix of one, a full
+// SHA256 fingerprint or an alias) from the trash back into this store and returns its fingerprint.  Identities are not
+// restored over a stored identity or with an alias used by a stored identity.
+func (s *IdentityStore) Restore(fingerprint string) (string, error) {
+
+	list, err := s.trashed()
+	if err != nil {
+		return "", err
+	}
+
+	latest := map[string]*trashed{}
+	stored := []string{}
+
+	for _, item := range list {
+
+		if _, found := latest[item.Fingerprint]; !found {
+			stored = append(stored, item.Fingerprint)
+		}
+
+		latest[item.Fingerprint] = item
+	}
+
+	resolved, err := stores.Resolve(fingerprint, stored, func(candidate string) (string, error) {
+
+		certificate, err := readCertificate(latest[candidate].file)
+		if err != nil {
+			return "", err
+		}
+
+		return certificates.FullFingerprint(certificate), nil
+	}, func() (map[string]string, error) {
+
+		aliases := map[string]string{}
+
+		for _, candidate := range stored {
+
+			record, err := readRecord(latest[candidate].file)
+			if err != nil {
+				return nil, err
+			}
+
+			if record.Metadata != nil && record.Metadata.Alias != "" {
+				aliases[record.Metadata.Alias] = candidate
+			}
+		}
+
+		return aliases, nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "error restoring identity from trash")
+	}
+
+	source := latest[resolved].file
+	destination := filepath.Join(s.directory, fmt.Sprintf("%s.json", resolved))
+
+	_, err = os.Stat(destination)
+	if err == nil {
+		return "", fmt.Errorf("error restoring identity [%s] already stored", resolved)
+	} else if !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "error restoring identity [%s]", resolved)
+	}
+
+	record, err := readRecord(source)
+	if err != nil {
+		return "", err
+	}
+
+	if record.Metadata != nil && record.Metadata.Alias != "" {
+
+		err = stores.AvailableAlias(s, resolved, record.Metadata.Alias)
+		if err != nil {
+			return "", errors.Wrapf(err, "error restoring identity [%s]", resolved)
+		}
+	}
+
+	record.Trashed = nil
+
+	err = writeRecord(destination, record)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Remove(source)
+	if err != nil {
+		return "", errors.Wrapf(err, "error removing restored identity from [%s]", source)
+	}
+
+	return resolved, nil
+}
+
+// Trash moves the identity with the provided fingerprint to the trash of this store recording the reason.
+func (s *IdentityStore) Trash(fingerprint string, reason string) error {
+
+	file, err := s.path(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	record, err := readRecord(file)
+	if err != nil {
+		return errors.Wrapf(err, "error deleting identity from [%s]", file)
+	}
+
+	now := time.Now().UTC()
+	record.Trashed = &trashing{Reason: reason, Time: now}
+
+	// Timestamps keep identities that are deleted, restored and deleted again (or re-created) from overwriting each other.
+	name := fmt.Sprintf("%s-%d.json", strings.TrimSuffix(filepath.Base(file), ".json"), now.UnixNano())
+	destination := filepath.Join(s.directory, trashDirectory, name)
+
+	err = writeRecord(destination, record)
+	if err != nil {
+		return errors.Wrapf(err, "error moving identity [%s] to trash", file)
+	}
+
+	err = os.Remove(file)
+	if err != nil {
+		return errors.Wrapf(err, "error deleting identity from [%s]", file)
+	}
+
+	return nil
+}
+
+// trashed returns the identities in the trash of this store ordered by when they were deleted.
+func (s *IdentityStore) trashed() ([]*trashed, error) {
+
+	directory := filepath.Join(s.directory, trashDirectory)
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading contents of [%s]", directory)
+	}
+
+	list := []*trashed{}
+
+	for _, file := range files {
+
+		record, err := readRecord(file)
+		if err != nil {
+			return nil, err
+		}
+
+		certificate, err := readCertificate(file)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &trashed{
+			Trashed: &stores.Trashed{
+				CommonName:  certificates.CommonName(certificate),
+				Fingerprint: certificates.Fingerprint(certificate),
+			},
+			file: file,
+		}
+
+		if record.Trashed != nil {
+			item.Deleted = record.Trashed.Time
+			item.Reason = record.Trashed.Reason
+		}
+
+		list = append(list, item)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Deleted.Before(list[j].Deleted)
+	})
+
+	return list, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTrash(t *testing.T) {
+
+	Convey("Trash", t, func() {
+
+		directory, err := ioutil.TempDir("", "trash")
+		So(err, ShouldBeNil)
+
+		store := NewIdentityStore(directory)
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := store.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		So(stores.Remove(store, fingerprint, "mistake"), ShouldBeNil)
+
+		Convey("it removes the identity from the store", func() {
+			_, err := store.Fetch(fingerprint)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("it lists the identity with the reason", func() {
+
+			trashed, err := store.ListTrash()
+			So(err, ShouldBeNil)
+			So(trashed, ShouldHaveLength, 1)
+			So(trashed[0].Fingerprint, ShouldEqual, fingerprint)
+			So(trashed[0].Reason, ShouldEqual, "mistake")
+		})
+
+		Convey("when the identity is restored", func() {
+
+			restored, err := store.Restore(fingerprint[:6])
+			So(err, ShouldBeNil)
+
+			Convey("it returns the identity with its key to the store", func() {
+
+				identity, err := store.Fetch(restored)
+				So(err, ShouldBeNil)
+				So(identity.Key, ShouldResemble, authority.Key)
+
+				trashed, err := store.ListTrash()
+				So(err, ShouldBeNil)
+				So(trashed, ShouldBeEmpty)
+			})
+		})
+
+		Convey("when the identity is stored again it is not restored over the stored identity", func() {
+
+			_, err := store.Upsert(authority)
+			So(err, ShouldBeNil)
+
+			_, err = store.Restore(fingerprint)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("when the trash is purged", func() {
+
+			purged, err := store.Purge(time.Now().Add(-time.Hour))
+			So(err, ShouldBeNil)
+			So(purged, ShouldBeEmpty)
+
+			purged, err = store.Purge(time.Now().Add(time.Second))
+			So(err, ShouldBeNil)
+			So(purged, ShouldHaveLength, 1)
+
+			Convey("it permanently deletes the identities deleted before the time", func() {
+
+				trashed, err := store.ListTrash()
+				So(err, ShouldBeNil)
+				So(trashed, ShouldBeEmpty)
+
+				_, err = store.Restore(fingerprint)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
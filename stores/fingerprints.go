@@ -0,0 +1,105 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+
+	// FingerprintLength defines the length of the (truncated) fingerprints identifying stored identities.
+	FingerprintLength = 12
+
+	// FullFingerprintLength defines the length of a full SHA256 fingerprint.
+	FullFingerprintLength = 64
+
+	// MinimumPrefixLength defines the shortest prefix accepted in place of a fingerprint.
+	MinimumPrefixLength = 4
+)
+
+// Resolve returns the stored fingerprint identified by a fingerprint, which may be a stored fingerprint, a unique prefix
+// of one (like a git short hash), a full SHA256 fingerprint or an alias.  The full function returns the full
+// fingerprint of a stored fingerprint so that the remainder of a full fingerprint can be checked and the aliases
+// function returns the stored fingerprints keyed by alias.  Fingerprints are case insensitive.
+func Resolve(fingerprint string, stored []string, full func(string) (string, error), aliases func() (map[string]string, error)) (string, error) {
+
+	if !hexadecimal.MatchString(fingerprint) {
+
+		aliased, err := aliases()
+		if err != nil {
+			return "", err
+		}
+
+		resolved, found := aliased[fingerprint]
+		if !found {
+			return "", fmt.Errorf("error resolving alias [%s] not found", fingerprint)
+		}
+
+		return resolved, nil
+	}
+
+	switch length := len(fingerprint); {
+	case length == FullFingerprintLength:
+
+		for _, candidate := range stored {
+
+			if !strings.EqualFold(candidate, fingerprint[:FingerprintLength]) {
+				continue
+			}
+
+			expected, err := full(candidate)
+			if err != nil {
+				return "", err
+			}
+
+			if strings.EqualFold(expected, fingerprint) {
+				return candidate, nil
+			}
+		}
+
+		return "", fmt.Errorf("error resolving fingerprint [%s] not found", fingerprint)
+
+	case length > FingerprintLength:
+		return "", fmt.Errorf("error resolving fingerprint [%s] must be a prefix of at most %d or exactly %d characters", fingerprint, FingerprintLength, FullFingerprintLength)
+	case length < MinimumPrefixLength:
+		return "", fmt.Errorf("error resolving fingerprint [%s] must be a prefix of at least %d characters", fingerprint, MinimumPrefixLength)
+	}
+
+	matches := []string{}
+
+	for _, candidate := range stored {
+
+		if strings.EqualFold(candidate, fingerprint) {
+			return candidate, nil
+		}
+
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(fingerprint)) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("error resolving fingerprint [%s] not found", fingerprint)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("error resolving fingerprint [%s] is ambiguous between [%s]", fingerprint, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/greymatter-io/acert/stores"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFingerprints(t *testing.T) {
+
+	Convey("Resolve", t, func() {
+
+		stored := []string{"3f2a9c1b7d4e", "3f2a00aa11bb", "9b1c2d3e4f5a"}
+		full := func(candidate string) (string, error) {
+			return candidate + strings.Repeat("0", stores.FullFingerprintLength-stores.FingerprintLength), nil
+		}
+		aliases := func() (map[string]string, error) {
+			return map[string]string{"web": "9b1c2d3e4f5a"}, nil
+		}
+
+		Convey("when the fingerprint is stored", func() {
+
+			resolved, err := stores.Resolve("9B1C2D3E4F5A", stored, full, aliases)
+
+			Convey("it returns the stored fingerprint", func() {
+				So(err, ShouldBeNil)
+				So(resolved, ShouldEqual, "9b1c2d3e4f5a")
+			})
+		})
+
+		Convey("when the fingerprint is a unique prefix", func() {
+
+			resolved, err := stores.Resolve("3f2a9", stored, full, aliases)
+
+			Convey("it returns the stored fingerprint", func() {
+				So(err, ShouldBeNil)
+				So(resolved, ShouldEqual, "3f2a9c1b7d4e")
+			})
+		})
+
+		Convey("when the fingerprint is an ambiguous prefix", func() {
+
+			_, err := stores.Resolve("3f2a", stored, full, aliases)
+
+			Convey("it returns an error listing the candidates", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "[3f2a00aa11bb, 3f2a9c1b7d4e]")
+			})
+		})
+
+		Convey("when the fingerprint is too short", func() {
+
+			_, err := stores.Resolve("3f2", stored, full, aliases)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the fingerprint is a full fingerprint", func() {
+
+			Convey("it returns the stored fingerprint if the remainder matches", func() {
+				resolved, err := stores.Resolve("9b1c2d3e4f5a"+strings.Repeat("0", 52), stored, full, aliases)
+				So(err, ShouldBeNil)
+				So(resolved, ShouldEqual, "9b1c2d3e4f5a")
+			})
+
+			Convey("it returns a non-nil error if the remainder does not match", func() {
+				_, err := stores.Resolve("9b1c2d3e4f5a"+strings.Repeat("1", 52), stored, full, aliases)
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the fingerprint is an alias", func() {
+
+			resolved, err := stores.Resolve("web", stored, full, aliases)
+
+			Convey("it returns the aliased fingerprint", func() {
+				So(err, ShouldBeNil)
+				So(resolved, ShouldEqual, "9b1c2d3e4f5a")
+			})
+		})
+
+		Convey("when the fingerprint does not match", func() {
+
+			_, err := stores.Resolve("ffffff", stored, full, aliases)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -14,7 +14,11 @@
 
 package stores
 
-import "github.com/greymatter-io/nautls/identities"
+import (
+	"crypto/x509"
+
+	"github.com/greymatter-io/acert/identities"
+)
 
 // IdentityStore defines the interface for identity stores.
 type IdentityStore interface {
@@ -25,9 +29,36 @@ type IdentityStore interface {
 	// Fetch returns the identity with the provided fingerprint from this store.
 	Fetch(fingerprint string) (*identities.Identity, error)
 
+	// FetchMetadata returns the metadata for the identity with the provided fingerprint from this store.
+	FetchMetadata(fingerprint string) (*Metadata, error)
+
 	// List returns the identities from this store.
 	List() ([]*identities.Identity, error)
 
-	// Upsert inserts or updates an identity into this store and returns the fingerprint.
+	// ListRecords returns the records of the identities from this store.  Unlike List the keys are not read (i.e., the
+	// keys of an encrypted store are not decrypted).
+	ListRecords() ([]*Record, error)
+
+	// UpdateMetadata replaces the metadata for the identity with the provided fingerprint in this store.
+	UpdateMetadata(fingerprint string, metadata *Metadata) error
+
+	// Upsert inserts or updates an identity into this store and returns the fingerprint.  The metadata of an existing
+	// identity is preserved.
 	Upsert(*identities.Identity) (string, error)
 }
+
+// Record defines an identity in a store without its key.
+type Record struct {
+
+	// Authorities defines the authorities of the identity ordered from its issuer to its root.
+	Authorities []*x509.Certificate
+
+	// Certificate defines the certificate of the identity.
+	Certificate *x509.Certificate
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string
+
+	// Metadata defines the metadata of the identity.
+	Metadata *Metadata
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/greymatter-io/acert/certificates"
+)
+
+// Issuer returns the fingerprint in the authority store of the authority that issued the identity with the provided
+// fingerprint.  The authority recorded when the identity was issued is returned if it is still stored, otherwise the
+// stored authorities are matched against the certificate of the identity (see MatchIssuer).  Only the certificates and
+// metadata of the stores are read.
+func Issuer(authorities IdentityStore, store IdentityStore, fingerprint string) (string, error) {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return "", err
+	}
+
+	record, err := FindRecord(records, fingerprint)
+	if err != nil {
+		return "", err
+	}
+
+	candidates, err := authorities.ListRecords()
+	if err != nil {
+		return "", err
+	}
+
+	issuer := MatchIssuer(candidates, record.Certificate, record.Authorities, record.Metadata.Authority)
+	if issuer == "" {
+		return "", fmt.Errorf("error finding issuing authority of [%s] no stored authority issued the certificate", record.Fingerprint)
+	}
+
+	return issuer, nil
+}
+
+// MatchIssuer returns the fingerprint of the candidate that issued a certificate (or an empty string if none did).  The
+// recorded fingerprint is returned without checking any signature if it is one of the candidates.  Otherwise the
+// candidates are matched by key identifiers and signature (see certificates.IssuedBy) and, if several match (e.g., an
+// authority renewed with the same key), the first authority in the chain of the certificate is preferred before the
+// lowest fingerprint.
+func MatchIssuer(candidates []*Record, certificate *x509.Certificate, chain []*x509.Certificate, recorded string) string {
+
+	if recorded != "" {
+		for _, candidate := range candidates {
+			if candidate.Fingerprint == recorded {
+				return recorded
+			}
+		}
+	}
+
+	fingerprint := certificates.Fingerprint(certificate)
+
+	preferred := ""
+	if len(chain) > 0 {
+		preferred = certificates.Fingerprint(chain[0])
+	}
+
+	issuer := ""
+
+	for _, candidate := range candidates {
+
+		if candidate.Fingerprint == fingerprint || !certificates.IssuedBy(certificate, candidate.Certificate) {
+			continue
+		}
+
+		if issuer != preferred && (candidate.Fingerprint == preferred || issuer == "" || candidate.Fingerprint < issuer) {
+			issuer = candidate.Fingerprint
+		}
+	}
+
+	return issuer
+}
+
+// RecordIssuer records the fingerprint in the authority store of the authority that issued the identity with the
+// provided fingerprint.
+func RecordIssuer(store IdentityStore, fingerprint string, authority string) error {
+
+	metadata, err := store.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	metadata.Authority = authority
+
+	return store.UpdateMetadata(fingerprint, metadata)
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIssuers(t *testing.T) {
+
+	Convey("MatchIssuer", t, func() {
+
+		issuing := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		leaf := tests.MustGenerateLeaf(t, issuing, keys.ECDSA, "P256")
+
+		candidates := []*stores.Record{record(other), record(issuing)}
+
+		Convey("when an authority issued the certificate it returns the fingerprint of the authority", func() {
+			So(stores.MatchIssuer(candidates, leaf.Certificate, leaf.Authorities, ""), ShouldEqual, certificates.Fingerprint(issuing.Certificate))
+		})
+
+		Convey("when the chain of the certificate begins with another certificate it returns the issuing authority", func() {
+			So(stores.MatchIssuer(candidates, leaf.Certificate, []*x509.Certificate{other.Certificate}, ""), ShouldEqual, certificates.Fingerprint(issuing.Certificate))
+		})
+
+		Convey("when the recorded authority is a candidate it returns the recorded authority", func() {
+			So(stores.MatchIssuer(candidates, leaf.Certificate, leaf.Authorities, certificates.Fingerprint(other.Certificate)), ShouldEqual, certificates.Fingerprint(other.Certificate))
+		})
+
+		Convey("when the recorded authority follows an authority that issued the certificate it returns the recorded authority", func() {
+			reordered := []*stores.Record{record(issuing), record(other)}
+			So(stores.MatchIssuer(reordered, leaf.Certificate, leaf.Authorities, certificates.Fingerprint(other.Certificate)), ShouldEqual, certificates.Fingerprint(other.Certificate))
+		})
+
+		Convey("when the recorded authority is not a candidate it returns the matching authority", func() {
+			So(stores.MatchIssuer(candidates, leaf.Certificate, leaf.Authorities, "e3b0c44298fc"), ShouldEqual, certificates.Fingerprint(issuing.Certificate))
+		})
+
+		Convey("when no candidate issued the certificate it returns an empty string", func() {
+			So(stores.MatchIssuer([]*stores.Record{record(other)}, leaf.Certificate, leaf.Authorities, ""), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Issuer", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		issuer, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+		So(err, ShouldBeNil)
+
+		Convey("when the issuing authority is recorded it returns the recorded authority", func() {
+
+			So(stores.RecordIssuer(leaves, fingerprint, issuer), ShouldBeNil)
+
+			found, err := stores.Issuer(authorities, leaves, fingerprint)
+			So(err, ShouldBeNil)
+			So(found, ShouldEqual, issuer)
+		})
+
+		Convey("when the issuing authority is not recorded it returns the matching authority", func() {
+			found, err := stores.Issuer(authorities, leaves, fingerprint)
+			So(err, ShouldBeNil)
+			So(found, ShouldEqual, issuer)
+		})
+
+		Convey("when the issuing authority is not stored it returns an error", func() {
+			So(authorities.Delete(issuer), ShouldBeNil)
+			_, err := stores.Issuer(authorities, leaves, fingerprint)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// record returns the record of an identity with empty metadata.
+func record(identity *identities.Identity) *stores.Record {
+	return &stores.Record{
+		Authorities: identity.Authorities,
+		Certificate: identity.Certificate,
+		Fingerprint: certificates.Fingerprint(identity.Certificate),
+		Metadata:    &stores.Metadata{},
+	}
+}
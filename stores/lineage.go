@@ -0,0 +1,105 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import "fmt"
+
+// Link records that the identity with the successor fingerprint is a renewal of the identity with the predecessor
+// fingerprint.
+func Link(store IdentityStore, predecessor string, successor string) error {
+
+	metadata, err := store.FetchMetadata(predecessor)
+	if err != nil {
+		return err
+	}
+
+	metadata.Successor = successor
+
+	err = store.UpdateMetadata(predecessor, metadata)
+	if err != nil {
+		return err
+	}
+
+	metadata, err = store.FetchMetadata(successor)
+	if err != nil {
+		return err
+	}
+
+	metadata.Predecessor = predecessor
+
+	return store.UpdateMetadata(successor, metadata)
+}
+
+// Lineage returns the fingerprints of the renewals of the identity with the provided fingerprint ordered from the
+// original identity to the latest renewal.  Renewals that are no longer in the store end the lineage.  Only the
+// certificates and metadata of the store are read.
+func Lineage(store IdentityStore, fingerprint string) ([]string, error) {
+
+	records, err := store.ListRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := FindRecord(records, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint = record.Fingerprint
+
+	metadata, err := store.FetchMetadata(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	lineage := []string{fingerprint}
+	visited := map[string]bool{fingerprint: true}
+
+	for current := metadata; current.Predecessor != ""; {
+
+		if visited[current.Predecessor] {
+			return nil, fmt.Errorf("error tracing lineage of [%s] cycle at [%s]", fingerprint, current.Predecessor)
+		}
+
+		predecessor := current.Predecessor
+
+		current, err = store.FetchMetadata(predecessor)
+		if err != nil {
+			break
+		}
+
+		lineage = append([]string{predecessor}, lineage...)
+		visited[predecessor] = true
+	}
+
+	for current := metadata; current.Successor != ""; {
+
+		if visited[current.Successor] {
+			return nil, fmt.Errorf("error tracing lineage of [%s] cycle at [%s]", fingerprint, current.Successor)
+		}
+
+		successor := current.Successor
+
+		current, err = store.FetchMetadata(successor)
+		if err != nil {
+			break
+		}
+
+		lineage = append(lineage, successor)
+		visited[successor] = true
+	}
+
+	return lineage, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"testing"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLineage(t *testing.T) {
+
+	Convey("Lineage", t, func() {
+
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprints := []string{}
+		for index := 0; index < 3; index++ {
+
+			fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+
+			fingerprints = append(fingerprints, fingerprint)
+		}
+
+		So(stores.Link(leaves, fingerprints[0], fingerprints[1]), ShouldBeNil)
+		So(stores.Link(leaves, fingerprints[1], fingerprints[2]), ShouldBeNil)
+
+		Convey("when traced from any renewal", func() {
+
+			Convey("it returns the renewals from the original to the latest", func() {
+
+				for _, fingerprint := range fingerprints {
+
+					lineage, err := stores.Lineage(leaves, fingerprint)
+
+					So(err, ShouldBeNil)
+					So(lineage, ShouldResemble, fingerprints)
+				}
+			})
+		})
+
+		Convey("when an identity has not been renewed", func() {
+
+			fingerprint, err := leaves.Upsert(tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256"))
+			So(err, ShouldBeNil)
+
+			lineage, err := stores.Lineage(leaves, fingerprint)
+
+			Convey("it returns only the identity", func() {
+				So(err, ShouldBeNil)
+				So(lineage, ShouldResemble, []string{fingerprint})
+			})
+		})
+	})
+}
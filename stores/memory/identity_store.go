@@ -16,31 +16,68 @@ package memory
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/greymatter-io/acert/certificates"
-	"github.com/greymatter-io/nautls/identities"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/stores"
 )
 
-// IdentityStore provides an in memory implementation of the IdentityStore interface.
+// IdentityStore provides an in memory implementation of the IdentityStore and RequestStore interfaces.
 type IdentityStore struct {
-	store map[string]*identities.Identity
+	metadata map[string]*stores.Metadata
+	requests map[string]*identities.Request
+	store    map[string]*identities.Identity
+}
+
+// NewIdentityStore returns a new identity store instance.
+func NewIdentityStore() *IdentityStore {
+	return &IdentityStore{
+		metadata: map[string]*stores.Metadata{},
+		requests: map[string]*identities.Request{},
+		store:    map[string]*identities.Identity{},
+	}
 }
 
 // Delete deletes the identity with the provided fingerprint from this store.
 func (s *IdentityStore) Delete(fingerprint string) error {
-	delete(s.store, fingerprint)
+
+	resolved, err := s.resolve(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	delete(s.metadata, resolved)
+	delete(s.store, resolved)
+
 	return nil
 }
 
 // Fetch returns the identity with the provided fingerprint from this store.
 func (s *IdentityStore) Fetch(fingerprint string) (*identities.Identity, error) {
 
-	identity, found := s.store[fingerprint]
+	resolved, err := s.resolve(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store[resolved], nil
+}
+
+// FetchMetadata returns the metadata for the identity with the provided fingerprint from this store.
+func (s *IdentityStore) FetchMetadata(fingerprint string) (*stores.Metadata, error) {
+
+	resolved, err := s.resolve(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, found := s.metadata[resolved]
 	if !found {
-		return nil, fmt.Errorf("identity not found [%s]", fingerprint)
+		return &stores.Metadata{}, nil
 	}
 
-	return identity, nil
+	return copyMetadata(metadata), nil
 }
 
 // List returns the identities from this store.
@@ -54,9 +91,109 @@ func (s *IdentityStore) List() ([]*identities.Identity, error) {
 	return result, nil
 }
 
+// ListRecords returns the records of the identities from this store ordered by fingerprint.
+func (s *IdentityStore) ListRecords() ([]*stores.Record, error) {
+
+	result := []*stores.Record{}
+	for fingerprint, identity := range s.store {
+
+		metadata, found := s.metadata[fingerprint]
+		if !found {
+			metadata = &stores.Metadata{}
+		}
+
+		result = append(result, &stores.Record{
+			Authorities: identity.Authorities,
+			Certificate: identity.Certificate,
+			Fingerprint: fingerprint,
+			Metadata:    copyMetadata(metadata),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+
+	return result, nil
+}
+
+// UpdateMetadata replaces the metadata for the identity with the provided fingerprint in this store.
+func (s *IdentityStore) UpdateMetadata(fingerprint string, metadata *stores.Metadata) error {
+
+	resolved, err := s.resolve(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	s.metadata[resolved] = copyMetadata(metadata)
+
+	return nil
+}
+
 // Upsert inserts or updates an identity into this store and returns the id.
 func (s *IdentityStore) Upsert(identity *identities.Identity) (string, error) {
+
 	fingerprint := certificates.Fingerprint(identity.Certificate)
+
+	if existing, found := s.store[fingerprint]; found && !existing.Certificate.Equal(identity.Certificate) {
+		return "", fmt.Errorf("error upserting identity [%s] fingerprint collides with stored identity [%s]", certificates.FullFingerprint(identity.Certificate), certificates.FullFingerprint(existing.Certificate))
+	}
+
 	s.store[fingerprint] = identity
+
 	return fingerprint, nil
 }
+
+// resolve returns the stored fingerprint identified by a fingerprint, a unique prefix of one, a full SHA256 fingerprint
+// or an alias.
+func (s *IdentityStore) resolve(fingerprint string) (string, error) {
+
+	stored := make([]string, 0, len(s.store))
+	for candidate := range s.store {
+		stored = append(stored, candidate)
+	}
+
+	return stores.Resolve(fingerprint, stored, func(candidate string) (string, error) {
+		return certificates.FullFingerprint(s.store[candidate].Certificate), nil
+	}, func() (map[string]string, error) {
+
+		aliases := map[string]string{}
+		for candidate, metadata := range s.metadata {
+			if metadata.Alias != "" {
+				aliases[metadata.Alias] = candidate
+			}
+		}
+
+		return aliases, nil
+	})
+}
+
+// copyMetadata returns a copy of metadata that shares no state with the original.
+func copyMetadata(metadata *stores.Metadata) *stores.Metadata {
+
+	copied := *metadata
+
+	if metadata.Labels != nil {
+		copied.Labels = make(map[string]string, len(metadata.Labels))
+		for key, value := range metadata.Labels {
+			copied.Labels[key] = value
+		}
+	}
+
+	if metadata.Serials != nil {
+		copied.Serials = make(map[string]string, len(metadata.Serials))
+		for serial, fingerprint := range metadata.Serials {
+			copied.Serials[serial] = fingerprint
+		}
+	}
+
+	if metadata.Revocations != nil {
+		copied.Revocations = make(map[string]*stores.Revocation, len(metadata.Revocations))
+		for serial, revocation := range metadata.Revocations {
+			revoked := *revocation
+			copied.Revocations[serial] = &revoked
+		}
+	}
+
+	return &copied
+}
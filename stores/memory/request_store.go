@@ -0,0 +1,89 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/stores"
+)
+
+// DeleteRequest deletes the request with the provided fingerprint from this store.
+func (s *IdentityStore) DeleteRequest(fingerprint string) error {
+
+	resolved, err := s.resolveRequest(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	delete(s.requests, resolved)
+
+	return nil
+}
+
+// FetchRequest returns the request with the provided fingerprint from this store.
+func (s *IdentityStore) FetchRequest(fingerprint string) (*identities.Request, error) {
+
+	resolved, err := s.resolveRequest(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.requests[resolved], nil
+}
+
+// ListRequests returns the requests from this store.
+func (s *IdentityStore) ListRequests() ([]*identities.Request, error) {
+
+	result := []*identities.Request{}
+	for _, value := range s.requests {
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// UpsertRequest inserts or updates a request into this store and returns the fingerprint.
+func (s *IdentityStore) UpsertRequest(request *identities.Request) (string, error) {
+
+	fingerprint := certificates.RequestFingerprint(request.CertificateRequest)
+
+	if existing, found := s.requests[fingerprint]; found && !bytes.Equal(existing.CertificateRequest.Raw, request.CertificateRequest.Raw) {
+		return "", fmt.Errorf("error upserting request [%s] fingerprint collides with stored request [%s]", certificates.FullRequestFingerprint(request.CertificateRequest), certificates.FullRequestFingerprint(existing.CertificateRequest))
+	}
+
+	s.requests[fingerprint] = request
+
+	return fingerprint, nil
+}
+
+// resolveRequest returns the stored fingerprint identified by a request fingerprint, a unique prefix of one or a full
+// SHA256 fingerprint.
+func (s *IdentityStore) resolveRequest(fingerprint string) (string, error) {
+
+	stored := make([]string, 0, len(s.requests))
+	for candidate := range s.requests {
+		stored = append(stored, candidate)
+	}
+
+	return stores.Resolve(fingerprint, stored, func(candidate string) (string, error) {
+		return certificates.FullRequestFingerprint(s.requests[candidate].CertificateRequest), nil
+	}, func() (map[string]string, error) {
+		return map[string]string{}, nil
+	})
+}
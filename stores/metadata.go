@@ -0,0 +1,93 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"time"
+
+	"github.com/greymatter-io/acert/identities"
+)
+
+// Metadata defines the information recorded about an identity in addition to the identity itself.
+type Metadata struct {
+
+	// Alias defines a name unique within the store that may be used in place of the fingerprint of the identity.
+	Alias string `json:"alias,omitempty"`
+
+	// Authority defines the fingerprint in the authority store of the authority that issued a leaf.
+	Authority string `json:"authority,omitempty"`
+
+	// CRLNumber defines the number of the last certificate revocation list generated by an authority.
+	CRLNumber int64 `json:"crlNumber,omitempty"`
+
+	// CRLURL defines the URL of the certificate revocation list of an authority embedded (as a CRL distribution point)
+	// in the certificates it issues.
+	CRLURL string `json:"crlURL,omitempty"`
+
+	// Labels defines arbitrary key value pairs used to select identities (e.g., env=staging).
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// OCSPURL defines the URL of the OCSP responder for an authority embedded (as authority information access) in the
+	// certificates it issues.
+	OCSPURL string `json:"ocspURL,omitempty"`
+
+	// Predecessor defines the fingerprint of the identity renewed to produce the identity.
+	Predecessor string `json:"predecessor,omitempty"`
+
+	// Revocations defines the certificates revoked by an authority keyed by serial number.
+	Revocations map[string]*Revocation `json:"revocations,omitempty"`
+
+	// Root defines whether the identity is a self signed root authority.
+	Root bool `json:"root,omitempty"`
+
+	// Serials defines the fingerprints of the certificates issued by an authority keyed by serial number.
+	Serials map[string]string `json:"serials,omitempty"`
+
+	// Successor defines the fingerprint of the identity produced by renewing the identity.
+	Successor string `json:"successor,omitempty"`
+}
+
+// Revocation defines the revocation of a certificate by an authority.
+type Revocation struct {
+
+	// Fingerprint defines the fingerprint of the revoked certificate.
+	Fingerprint string `json:"fingerprint"`
+
+	// Reason defines the RFC 5280 reason code for the revocation.
+	Reason int `json:"reason"`
+
+	// Time defines when the certificate was revoked.
+	Time time.Time `json:"time"`
+}
+
+// ApplyMetadata applies the settings recorded in the metadata of the authority with the provided fingerprint (e.g.,
+// the CRL distribution point and OCSP responder) to a template for a certificate issued by the authority.
+func ApplyMetadata(authorities IdentityStore, fingerprint string, template *identities.Template) error {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	if metadata.CRLURL != "" {
+		template.CRLDistributionPoints = []string{metadata.CRLURL}
+	}
+
+	if metadata.OCSPURL != "" {
+		template.OCSPServer = []string{metadata.OCSPURL}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import "github.com/greymatter-io/acert/certificates"
+
+// FindRecord returns the record identified by a fingerprint, a unique prefix of one, a full SHA256 fingerprint or an
+// alias (see Resolve) among the records.
+func FindRecord(records []*Record, fingerprint string) (*Record, error) {
+
+	stored := make([]string, len(records))
+	indexed := make(map[string]*Record, len(records))
+
+	for index, record := range records {
+		stored[index] = record.Fingerprint
+		indexed[record.Fingerprint] = record
+	}
+
+	resolved, err := Resolve(fingerprint, stored, func(candidate string) (string, error) {
+		return certificates.FullFingerprint(indexed[candidate].Certificate), nil
+	}, func() (map[string]string, error) {
+
+		aliases := map[string]string{}
+		for _, record := range records {
+			if record.Metadata.Alias != "" {
+				aliases[record.Metadata.Alias] = record.Fingerprint
+			}
+		}
+
+		return aliases, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return indexed[resolved], nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"testing"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecords(t *testing.T) {
+
+	Convey("FindRecord", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		So(stores.SetAlias(authorities, fingerprint, "root"), ShouldBeNil)
+
+		records, err := authorities.ListRecords()
+		So(err, ShouldBeNil)
+
+		Convey("when the fingerprint is stored", func() {
+
+			record, err := stores.FindRecord(records, fingerprint)
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the record", func() {
+				So(record.Certificate.Equal(authority.Certificate), ShouldBeTrue)
+			})
+		})
+
+		Convey("when the full fingerprint is provided", func() {
+
+			record, err := stores.FindRecord(records, certificates.FullFingerprint(authority.Certificate))
+
+			Convey("it returns the record", func() {
+				So(err, ShouldBeNil)
+				So(record.Fingerprint, ShouldEqual, fingerprint)
+			})
+		})
+
+		Convey("when the alias is provided", func() {
+
+			record, err := stores.FindRecord(records, "root")
+
+			Convey("it returns the record", func() {
+				So(err, ShouldBeNil)
+				So(record.Fingerprint, ShouldEqual, fingerprint)
+			})
+		})
+
+		Convey("when the fingerprint is not stored", func() {
+
+			record, err := stores.FindRecord(records, "ffffffffffff")
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it returns a nil record", func() {
+				So(record, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import "github.com/greymatter-io/acert/identities"
+
+// RequestStore defines the interface for stores of pending requests (i.e., certificate requests awaiting certificates
+// from external authorities).
+type RequestStore interface {
+
+	// DeleteRequest deletes the request with the provided fingerprint from this store.
+	DeleteRequest(fingerprint string) error
+
+	// FetchRequest returns the request with the provided fingerprint from this store.
+	FetchRequest(fingerprint string) (*identities.Request, error)
+
+	// ListRequests returns the requests from this store.
+	ListRequests() ([]*identities.Request, error)
+
+	// UpsertRequest inserts or updates a request into this store and returns the fingerprint.
+	UpsertRequest(*identities.Request) (string, error)
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/serials"
+)
+
+// Revoke records the revocation of a certificate issued by the authority with the provided fingerprint.  An error is
+// returned if the certificate has already been revoked.
+func Revoke(authorities IdentityStore, fingerprint string, certificate *x509.Certificate, reason int, revoked time.Time) error {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	serial := serials.Format(certificate.SerialNumber)
+
+	if _, found := metadata.Revocations[serial]; found {
+		return fmt.Errorf("error revoking certificate [%s] already revoked by authority [%s]", certificates.Fingerprint(certificate), fingerprint)
+	}
+
+	if metadata.Revocations == nil {
+		metadata.Revocations = map[string]*Revocation{}
+	}
+
+	metadata.Revocations[serial] = &Revocation{
+		Fingerprint: certificates.Fingerprint(certificate),
+		Reason:      reason,
+		Time:        revoked.UTC(),
+	}
+
+	return authorities.UpdateMetadata(fingerprint, metadata)
+}
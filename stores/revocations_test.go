@@ -0,0 +1,91 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRevocations(t *testing.T) {
+
+	Convey("Revoke", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+		revoked := time.Date(2019, time.June, 1, 12, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+
+		err = stores.Revoke(authorities, fingerprint, leaf.Certificate, 4, revoked)
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it persists the revocation with the reason", func() {
+
+			metadata, err := authorities.FetchMetadata(fingerprint)
+			So(err, ShouldBeNil)
+
+			revocation := metadata.Revocations[serials.Format(leaf.Certificate.SerialNumber)]
+
+			So(revocation, ShouldNotBeNil)
+			So(revocation.Fingerprint, ShouldEqual, certificates.Fingerprint(leaf.Certificate))
+			So(revocation.Reason, ShouldEqual, 4)
+			So(revocation.Time, ShouldEqual, revoked.UTC())
+		})
+
+		Convey("when the certificate is revoked again", func() {
+
+			err := stores.Revoke(authorities, fingerprint, leaf.Certificate, 1, revoked.Add(time.Hour))
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it preserves the original revocation", func() {
+
+				metadata, err := authorities.FetchMetadata(fingerprint)
+				So(err, ShouldBeNil)
+
+				revocation := metadata.Revocations[serials.Format(leaf.Certificate.SerialNumber)]
+
+				So(revocation.Reason, ShouldEqual, 4)
+				So(revocation.Time, ShouldEqual, revoked.UTC())
+			})
+		})
+
+		Convey("when the authority does not exist", func() {
+
+			err := stores.Revoke(authorities, "e3b0c44298fc", leaf.Certificate, 4, revoked)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import (
+	"crypto/x509"
+	"fmt"
+	"math/big"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/serials"
+	"github.com/pkg/errors"
+)
+
+// Lookup returns the fingerprint of the certificate with a serial number issued by the authority with the provided
+// fingerprint.
+func Lookup(authorities IdentityStore, fingerprint string, serial *big.Int) (string, error) {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return "", err
+	}
+
+	issued, found := metadata.Serials[serials.Format(serial)]
+	if !found {
+		return "", fmt.Errorf("error looking up serial number [%s] not issued by authority [%s]", serials.Format(serial), fingerprint)
+	}
+
+	return issued, nil
+}
+
+// Register records the serial number of a certificate issued by the authority with the provided fingerprint.  An error
+// is returned if the authority has already issued a different certificate with the serial number.
+func Register(authorities IdentityStore, fingerprint string, certificate *x509.Certificate) error {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	serial := serials.Format(certificate.SerialNumber)
+	issued := certificates.Fingerprint(certificate)
+
+	if existing, found := metadata.Serials[serial]; found && existing != issued {
+		return fmt.Errorf("error registering serial number [%s] already issued by authority [%s] to [%s]", serial, fingerprint, existing)
+	}
+
+	if metadata.Serials == nil {
+		metadata.Serials = map[string]string{}
+	}
+
+	metadata.Serials[serial] = issued
+
+	return authorities.UpdateMetadata(fingerprint, metadata)
+}
+
+// Store registers the serial number of an identity issued by the authority with the provided fingerprint and upserts
+// it into a store.  The serial number is registered before the identity is stored so that a conflicting serial number
+// never leaves a half registered identity in (or in the trash of) the store and is unregistered if the identity cannot
+// be stored so that an authority never records a serial number for an identity that does not exist.
+func Store(authorities IdentityStore, fingerprint string, store IdentityStore, identity *identities.Identity) (string, error) {
+
+	_, lookupErr := Lookup(authorities, fingerprint, identity.Certificate.SerialNumber)
+
+	err := Register(authorities, fingerprint, identity.Certificate)
+	if err != nil {
+		return "", err
+	}
+
+	issued, err := store.Upsert(identity)
+	if err != nil {
+
+		// The serial number was registered to this certificate before this call and remains valid.
+		if lookupErr == nil {
+			return "", err
+		}
+
+		if unregisterErr := unregister(authorities, fingerprint, identity.Certificate); unregisterErr != nil {
+			return "", errors.Wrapf(unregisterErr, "error unregistering serial number after failing to store [%s]", err)
+		}
+
+		return "", err
+	}
+
+	return issued, nil
+}
+
+// unregister removes the serial number of a certificate issued by the authority with the provided fingerprint.
+func unregister(authorities IdentityStore, fingerprint string, certificate *x509.Certificate) error {
+
+	metadata, err := authorities.FetchMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	delete(metadata.Serials, serials.Format(certificate.SerialNumber))
+
+	return authorities.UpdateMetadata(fingerprint, metadata)
+}
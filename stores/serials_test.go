@@ -0,0 +1,193 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	"github.com/greymatter-io/acert/stores"
+	"github.com/greymatter-io/acert/stores/filesystem"
+	"github.com/greymatter-io/acert/stores/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSerials(t *testing.T) {
+
+	Convey("Register", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+		err = stores.Register(authorities, fingerprint, leaf.Certificate)
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it registers the serial number for lookup", func() {
+
+			issued, err := stores.Lookup(authorities, fingerprint, leaf.Certificate.SerialNumber)
+
+			So(err, ShouldBeNil)
+			So(issued, ShouldEqual, certificates.Fingerprint(leaf.Certificate))
+		})
+
+		Convey("when the serial number is registered to another certificate", func() {
+
+			other := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+			other.Certificate.SerialNumber = leaf.Certificate.SerialNumber
+
+			err := stores.Register(authorities, fingerprint, other.Certificate)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the serial number is not registered", func() {
+
+			_, err := stores.Lookup(authorities, fingerprint, big.NewInt(1))
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Store", t, func() {
+
+		authorities := memory.NewIdentityStore()
+		leaves := memory.NewIdentityStore()
+		authority := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+		fingerprint, err := authorities.Upsert(authority)
+		So(err, ShouldBeNil)
+
+		leaf := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+		issued, err := stores.Store(authorities, fingerprint, leaves, leaf)
+
+		Convey("it returns a nil error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("it stores the identity", func() {
+
+			fetched, err := leaves.Fetch(issued)
+
+			So(err, ShouldBeNil)
+			So(fetched.Certificate.Raw, ShouldResemble, leaf.Certificate.Raw)
+		})
+
+		Convey("it registers the serial number of the stored identity", func() {
+
+			registered, err := stores.Lookup(authorities, fingerprint, leaf.Certificate.SerialNumber)
+
+			So(err, ShouldBeNil)
+			So(registered, ShouldEqual, issued)
+		})
+
+		Convey("when the serial number is registered to another certificate", func() {
+
+			other := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+			other.Certificate.SerialNumber = leaf.Certificate.SerialNumber
+
+			_, err := stores.Store(authorities, fingerprint, leaves, other)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it does not leave the identity in the store", func() {
+
+				_, err := leaves.Fetch(certificates.Fingerprint(other.Certificate))
+
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("when the serial number is registered to another certificate with a trash", func() {
+
+			directory, err := ioutil.TempDir("", "serials")
+			So(err, ShouldBeNil)
+
+			trashed := filesystem.NewIdentityStore(directory)
+
+			other := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+			other.Certificate.SerialNumber = leaf.Certificate.SerialNumber
+
+			_, err = stores.Store(authorities, fingerprint, trashed, other)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it does not leave the identity in the store", func() {
+
+				records, err := trashed.ListRecords()
+
+				So(err, ShouldBeNil)
+				So(records, ShouldBeEmpty)
+			})
+
+			Convey("it does not leave the identity in the trash", func() {
+
+				listed, err := trashed.ListTrash()
+
+				So(err, ShouldBeNil)
+				So(listed, ShouldBeEmpty)
+			})
+		})
+
+		Convey("when the identity cannot be stored", func() {
+
+			other := tests.MustGenerateLeaf(t, authority, keys.ECDSA, "P256")
+
+			_, err := stores.Store(authorities, fingerprint, &failingStore{leaves}, other)
+
+			Convey("it returns a non-nil error", func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("it does not register the serial number", func() {
+
+				_, err := stores.Lookup(authorities, fingerprint, other.Certificate.SerialNumber)
+
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+// failingStore defines an identity store that fails to upsert identities.
+type failingStore struct {
+	stores.IdentityStore
+}
+
+// Upsert returns an error.
+func (s *failingStore) Upsert(identity *identities.Identity) (string, error) {
+	return "", fmt.Errorf("error upserting [%s]", identity.Certificate.Subject.CommonName)
+}
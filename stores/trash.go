@@ -0,0 +1,64 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stores
+
+import "time"
+
+// Trash defines the interface for identity stores that move deleted identities to a trash from which they may be
+// restored.  Identity stores opt into recoverable deletion by implementing this interface in addition to IdentityStore
+// (see Remove).
+type Trash interface {
+
+	// ListTrash returns the identities in the trash of this store ordered by when they were deleted.
+	ListTrash() ([]*Trashed, error)
+
+	// Purge permanently deletes the identities moved to the trash of this store before the provided time and returns
+	// the purged identities.
+	Purge(before time.Time) ([]*Trashed, error)
+
+	// Restore moves the most recently deleted identity with the provided fingerprint from the trash back into this
+	// store and returns its fingerprint.
+	Restore(fingerprint string) (string, error)
+
+	// Trash moves the identity with the provided fingerprint to the trash of this store recording the reason.
+	Trash(fingerprint string, reason string) error
+}
+
+// Trashed defines an identity in the trash of a store.
+type Trashed struct {
+
+	// CommonName defines the common name of the identity.
+	CommonName string `json:"commonName"`
+
+	// Deleted defines when the identity was moved to the trash.
+	Deleted time.Time `json:"deleted"`
+
+	// Fingerprint defines the fingerprint of the identity.
+	Fingerprint string `json:"fingerprint"`
+
+	// Reason defines why the identity was deleted.
+	Reason string `json:"reason"`
+}
+
+// Remove deletes the identity with the provided fingerprint from a store moving it to the trash with the reason if the
+// store implements Trash.
+func Remove(store IdentityStore, fingerprint string, reason string) error {
+
+	if trash, ok := store.(Trash); ok {
+		return trash.Trash(fingerprint, reason)
+	}
+
+	return store.Delete(fingerprint)
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greymatter-io/acert/stores"
+	"github.com/pkg/errors"
+)
+
+// Item defines an identity in the trash of a store of a type (e.g., authority or leaf).
+type Item struct {
+	*stores.Trashed
+
+	// Type defines the type of the identity (e.g., authority or leaf).
+	Type string `json:"type"`
+}
+
+// List returns the identities in the trash of stores keyed by the type of their identities (e.g., authority or leaf)
+// that were deleted before the provided time ordered by when they were deleted.
+func List(trashes map[string]stores.Trash, before time.Time) ([]*Item, error) {
+
+	items := []*Item{}
+
+	for identityType, trash := range trashes {
+
+		trashed, err := trash.ListTrash()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range trashed {
+			if item.Deleted.Before(before) {
+				items = append(items, &Item{Trashed: item, Type: identityType})
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+
+		if !items[i].Deleted.Equal(items[j].Deleted) {
+			return items[i].Deleted.Before(items[j].Deleted)
+		}
+
+		return items[i].Fingerprint < items[j].Fingerprint
+	})
+
+	return items, nil
+}
+
+// Format returns the items in the provided output format (i.e., json or text).
+func Format(items []*Item, output string) ([]byte, error) {
+
+	switch output {
+	case "json":
+
+		bytes, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding trash as json")
+		}
+
+		return append(bytes, '\n'), nil
+
+	case "text":
+
+		var buffer bytes.Buffer
+
+		writer := tabwriter.NewWriter(&buffer, 0, 8, 2, ' ', 0)
+
+		for _, item := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", item.Fingerprint, item.Type, item.CommonName, item.Deleted.Format(time.RFC3339), item.Reason)
+		}
+
+		writer.Flush()
+
+		return buffer.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("error parsing output [%s] must be one of [json, text]", output)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trash
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/stores"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fake defines a trash with a fixed list of trashed identities.
+type fake []*stores.Trashed
+
+// ListTrash returns the trashed identities.
+func (f fake) ListTrash() ([]*stores.Trashed, error) {
+	return f, nil
+}
+
+// Purge is not supported.
+func (f fake) Purge(before time.Time) ([]*stores.Trashed, error) {
+	return nil, nil
+}
+
+// Restore is not supported.
+func (f fake) Restore(fingerprint string) (string, error) {
+	return "", nil
+}
+
+// Trash is not supported.
+func (f fake) Trash(fingerprint string, reason string) error {
+	return nil
+}
+
+func TestTrash(t *testing.T) {
+
+	now := time.Date(2019, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	trashes := map[string]stores.Trash{
+		"authority": fake{
+			{CommonName: "Acert (Root)", Deleted: now.Add(-3 * time.Hour), Fingerprint: "aaaaaaaaaaaa", Reason: "deleted by authorities delete"},
+			{CommonName: "Acert (Intermediate)", Deleted: now.Add(-1 * time.Hour), Fingerprint: "bbbbbbbbbbbb", Reason: "deleted with authority [aaaaaaaaaaaa]"},
+		},
+		"leaf": fake{
+			{CommonName: "acert.test", Deleted: now.Add(-2 * time.Hour), Fingerprint: "cccccccccccc", Reason: "deleted by leaves delete"},
+			{CommonName: "other.test", Deleted: now.Add(-1 * time.Hour), Fingerprint: "000000000000", Reason: "deleted by leaves delete"},
+		},
+	}
+
+	Convey("List", t, func() {
+
+		Convey("it returns the items of every store ordered by when they were deleted and then by fingerprint", func() {
+
+			items, err := List(trashes, now)
+			So(err, ShouldBeNil)
+
+			fingerprints := []string{}
+			types := []string{}
+			for _, item := range items {
+				fingerprints = append(fingerprints, item.Fingerprint)
+				types = append(types, item.Type)
+			}
+
+			So(fingerprints, ShouldResemble, []string{"aaaaaaaaaaaa", "cccccccccccc", "000000000000", "bbbbbbbbbbbb"})
+			So(types, ShouldResemble, []string{"authority", "leaf", "leaf", "authority"})
+		})
+
+		Convey("it returns only the items deleted before the cutoff", func() {
+
+			items, err := List(trashes, now.Add(-90*time.Minute))
+			So(err, ShouldBeNil)
+			So(items, ShouldHaveLength, 2)
+			So(items[0].Fingerprint, ShouldEqual, "aaaaaaaaaaaa")
+			So(items[1].Fingerprint, ShouldEqual, "cccccccccccc")
+		})
+
+		Convey("when the cutoff is the time of a deletion it excludes the deletion", func() {
+
+			items, err := List(trashes, now.Add(-3*time.Hour))
+			So(err, ShouldBeNil)
+			So(items, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Format", t, func() {
+
+		items, err := List(trashes, now)
+		So(err, ShouldBeNil)
+
+		Convey("when the output is json it returns every field of the items", func() {
+
+			bytes, err := Format(items[:1], "json")
+			So(err, ShouldBeNil)
+
+			decoded := []map[string]interface{}{}
+			So(json.Unmarshal(bytes, &decoded), ShouldBeNil)
+			So(decoded, ShouldResemble, []map[string]interface{}{{
+				"commonName":  "Acert (Root)",
+				"deleted":     "2019-06-01T09:00:00Z",
+				"fingerprint": "aaaaaaaaaaaa",
+				"reason":      "deleted by authorities delete",
+				"type":        "authority",
+			}})
+		})
+
+		Convey("when the output is text it returns a line for each item", func() {
+
+			bytes, err := Format(items, "text")
+			So(err, ShouldBeNil)
+
+			lines := strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n")
+			So(lines, ShouldHaveLength, 4)
+			So(strings.Fields(lines[0])[:3], ShouldResemble, []string{"aaaaaaaaaaaa", "authority", "Acert"})
+			So(lines[0], ShouldContainSubstring, "2019-06-01T09:00:00Z")
+			So(lines[0], ShouldEndWith, "deleted by authorities delete")
+		})
+
+		Convey("when there are no items the text output is empty", func() {
+
+			bytes, err := Format([]*Item{}, "text")
+			So(err, ShouldBeNil)
+			So(bytes, ShouldBeEmpty)
+		})
+
+		Convey("when the output is unknown it returns an error", func() {
+			_, err := Format(items, "yaml")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
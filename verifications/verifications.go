@@ -0,0 +1,279 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifications
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/greymatter-io/acert/certificates"
+	"github.com/greymatter-io/acert/identities"
+)
+
+// Options defines the options for verifying a certificate.
+type Options struct {
+
+	// DNSName defines the DNS name (or IP address) the certificate must be valid for (empty to skip the check).
+	DNSName string
+
+	// Purpose defines the extended key usage the chain must permit.
+	Purpose x509.ExtKeyUsage
+
+	// Time defines the time at which the chain must be valid (zero selects the current time).
+	Time time.Time
+}
+
+// Verify returns the chain (from the certificate to a root) built by verifying a certificate against the provided roots
+// using the provided intermediates.  Errors explain why the certificate failed to verify (e.g., the issuer is not
+// trusted, an authority in the chain has expired, the DNS name is not a SAN or the purpose is not permitted).
+func Verify(certificate *x509.Certificate, intermediates []*x509.Certificate, roots []*x509.Certificate, options Options) ([]*x509.Certificate, error) {
+
+	if options.Time.IsZero() {
+		options.Time = time.Now()
+	}
+
+	intermediatePool := x509.NewCertPool()
+	for _, intermediate := range intermediates {
+		intermediatePool.AddCert(intermediate)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+
+	chains, err := certificate.Verify(x509.VerifyOptions{
+		CurrentTime:   options.Time,
+		DNSName:       options.DNSName,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{options.Purpose},
+		Roots:         rootPool,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error verifying certificate [%s] %s", certificates.Fingerprint(certificate), explain(err, certificate, intermediates, roots, options))
+	}
+
+	shortest := chains[0]
+	for _, chain := range chains {
+		if len(chain) < len(shortest) {
+			shortest = chain
+		}
+	}
+
+	return shortest, nil
+}
+
+// Roots returns the authorities trusted as roots (i.e., those that are self signed).  An authority that is not self
+// signed is never trusted implicitly (even if no other authority signed it) so that a partial chain cannot verify
+// against itself.
+func Roots(authorities []*x509.Certificate) []*x509.Certificate {
+
+	roots := []*x509.Certificate{}
+
+	for _, authority := range authorities {
+		if identities.SelfSigned(authority) {
+			roots = append(roots, authority)
+		}
+	}
+
+	return roots
+}
+
+// explain returns an explanation of an error returned verifying a certificate.
+func explain(err error, certificate *x509.Certificate, intermediates []*x509.Certificate, roots []*x509.Certificate, options Options) string {
+
+	switch err := err.(type) {
+	case x509.HostnameError:
+		return fmt.Sprintf("SAN mismatch [%s] is not one of the DNS names [%s] or IP addresses [%s]", err.Host, strings.Join(err.Certificate.DNSNames, ", "), addresses(err.Certificate.IPAddresses))
+	case x509.CertificateInvalidError:
+		return explainInvalid(err, certificate, intermediates, roots, options)
+	case x509.UnknownAuthorityError:
+		return explainUnknown(err, certificate, intermediates, roots, options)
+	default:
+		return err.Error()
+	}
+}
+
+// explainInvalid returns an explanation of an invalid certificate error.
+func explainInvalid(err x509.CertificateInvalidError, certificate *x509.Certificate, intermediates []*x509.Certificate, roots []*x509.Certificate, options Options) string {
+
+	subject := describe(err.Cert, certificate)
+
+	switch err.Reason {
+	case x509.Expired:
+		return validity(err.Cert, subject, options.Time)
+	case x509.IncompatibleUsage:
+
+		chain := []string{}
+		for _, link := range walk(certificate, append(append([]*x509.Certificate{}, intermediates...), roots...), roots) {
+			chain = append(chain, fmt.Sprintf("%s permits [%s]", describe(link, certificate), usages(link)))
+		}
+
+		return fmt.Sprintf("usage not permitted [%s] is not permitted by the chain (%s)", certificates.ExtKeyUsageName(options.Purpose), strings.Join(chain, ", "))
+
+	case x509.NotAuthorizedToSign:
+		return fmt.Sprintf("wrong issuer %s is not an authority", subject)
+	case x509.CANotAuthorizedForThisName:
+		return fmt.Sprintf("name not permitted by %s (%s)", subject, err.Detail)
+	case x509.TooManyIntermediates:
+		return fmt.Sprintf("path length exceeded for %s", subject)
+	default:
+		return err.Error()
+	}
+}
+
+// explainUnknown returns an explanation of an unknown authority error by walking the chain of the certificate.
+func explainUnknown(err x509.UnknownAuthorityError, certificate *x509.Certificate, intermediates []*x509.Certificate, roots []*x509.Certificate, options Options) string {
+
+	candidates := append(append([]*x509.Certificate{}, intermediates...), roots...)
+	current := certificate
+
+	for _, link := range walk(certificate, candidates, roots)[1:] {
+
+		if explanation := validity(link, describe(link, certificate), options.Time); explanation != "" {
+			return explanation
+		}
+
+		current = link
+	}
+
+	if contains(roots, current) {
+		return err.Error()
+	}
+
+	if identities.SelfSigned(current) {
+		return fmt.Sprintf("wrong issuer the chain ends at %s which is not a trusted root", describe(current, certificate))
+	}
+
+	named := []string{}
+	for _, candidate := range candidates {
+
+		fingerprint := certificates.Fingerprint(candidate)
+
+		if bytes.Equal(candidate.RawSubject, current.RawIssuer) && !strings.Contains(strings.Join(named, ","), fingerprint) {
+			named = append(named, fingerprint)
+		}
+	}
+
+	if len(named) > 0 {
+		return fmt.Sprintf("wrong issuer %s was not signed by the authorities named [%s] (%s)", describe(current, certificate), current.Issuer, strings.Join(named, ", "))
+	}
+
+	return fmt.Sprintf("wrong issuer the issuer [%s] of %s is not a trusted authority", current.Issuer, describe(current, certificate))
+}
+
+// addresses returns a comma separated list of IP addresses.
+func addresses(ips []net.IP) string {
+
+	values := make([]string, len(ips))
+	for index, ip := range ips {
+		values[index] = ip.String()
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// contains returns true if a certificate is one of the candidates.
+func contains(candidates []*x509.Certificate, certificate *x509.Certificate) bool {
+
+	for _, candidate := range candidates {
+		if candidate.Equal(certificate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describe returns a description of a certificate being verified or an authority in its chain.
+func describe(certificate *x509.Certificate, leaf *x509.Certificate) string {
+
+	role := "authority"
+	if certificate.Equal(leaf) {
+		role = "certificate"
+	}
+
+	return fmt.Sprintf("%s [%s] (%s)", role, certificates.Fingerprint(certificate), certificate.Subject.CommonName)
+}
+
+// signers returns the candidates other than the certificate itself that issued a certificate.
+func signers(certificate *x509.Certificate, candidates []*x509.Certificate) []*x509.Certificate {
+
+	result := []*x509.Certificate{}
+
+	for _, candidate := range candidates {
+		if !candidate.Equal(certificate) && certificates.IssuedBy(certificate, candidate) {
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
+
+// usages returns a comma separated list of the extended key usages of a certificate (any if unrestricted).
+func usages(certificate *x509.Certificate) string {
+
+	if len(certificate.ExtKeyUsage) == 0 && len(certificate.UnknownExtKeyUsage) == 0 {
+		return "any"
+	}
+
+	names := []string{}
+	for _, usage := range certificate.ExtKeyUsage {
+		names = append(names, certificates.ExtKeyUsageName(usage))
+	}
+
+	for _, usage := range certificate.UnknownExtKeyUsage {
+		names = append(names, usage.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// validity returns an explanation if a certificate is not valid at a time or an empty string if it is.
+func validity(certificate *x509.Certificate, subject string, at time.Time) string {
+
+	switch {
+	case at.After(certificate.NotAfter):
+		return fmt.Sprintf("expired %s expired at %s", subject, certificate.NotAfter.Format(time.RFC3339))
+	case at.Before(certificate.NotBefore):
+		return fmt.Sprintf("not yet valid %s is not valid until %s", subject, certificate.NotBefore.Format(time.RFC3339))
+	default:
+		return ""
+	}
+}
+
+// walk returns the chain from a certificate following the first signer of each link among the candidates and stopping
+// at a root, at a certificate without a signer or at a cycle.
+func walk(certificate *x509.Certificate, candidates []*x509.Certificate, roots []*x509.Certificate) []*x509.Certificate {
+
+	chain := []*x509.Certificate{certificate}
+
+	for current := certificate; !contains(roots, current); {
+
+		found := signers(current, candidates)
+		if len(found) == 0 || contains(chain, found[0]) {
+			break
+		}
+
+		current = found[0]
+		chain = append(chain, current)
+	}
+
+	return chain
+}
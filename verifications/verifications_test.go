@@ -0,0 +1,102 @@
+// Copyright 2019 Decipher Technology Studios
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifications
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/greymatter-io/acert/internal/tests"
+	"github.com/greymatter-io/acert/keys"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVerifications(t *testing.T) {
+
+	Convey("Verify", t, func() {
+
+		root := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+		intermediate := tests.MustGenerateIntermediate(t, root, keys.ECDSA, "P256", time.Now())
+		leaf := tests.MustGenerateLeaf(t, intermediate, keys.ECDSA, "P256")
+
+		intermediates := []*x509.Certificate{intermediate.Certificate}
+		roots := Roots([]*x509.Certificate{root.Certificate, intermediate.Certificate})
+
+		Convey("it trusts only the self signed authority as a root", func() {
+			So(roots, ShouldHaveLength, 1)
+			So(roots[0].Equal(root.Certificate), ShouldBeTrue)
+		})
+
+		Convey("it does not trust an authority without a signer among the authorities", func() {
+			So(Roots([]*x509.Certificate{intermediate.Certificate}), ShouldBeEmpty)
+		})
+
+		Convey("when the chain is valid", func() {
+
+			chain, err := Verify(leaf.Certificate, intermediates, roots, Options{DNSName: "acert.test", Purpose: x509.ExtKeyUsageServerAuth})
+
+			Convey("it returns a nil error", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("it returns the chain from the leaf to the root", func() {
+				So(chain, ShouldHaveLength, 3)
+				So(chain[2].Equal(root.Certificate), ShouldBeTrue)
+			})
+		})
+
+		Convey("when the DNS name is not a SAN", func() {
+
+			_, err := Verify(leaf.Certificate, intermediates, roots, Options{DNSName: "other.test", Purpose: x509.ExtKeyUsageAny})
+
+			Convey("it explains the SAN mismatch", func() {
+				So(err.Error(), ShouldContainSubstring, "SAN mismatch [other.test]")
+			})
+		})
+
+		Convey("when the purpose is not permitted", func() {
+
+			_, err := Verify(leaf.Certificate, intermediates, roots, Options{Purpose: x509.ExtKeyUsageCodeSigning})
+
+			Convey("it explains the usage is not permitted", func() {
+				So(err.Error(), ShouldContainSubstring, "usage not permitted [codeSigning]")
+			})
+		})
+
+		Convey("when the root did not issue the chain", func() {
+
+			other := tests.MustGenerateAuthority(t, keys.ECDSA, "P256")
+
+			_, err := Verify(leaf.Certificate, intermediates, []*x509.Certificate{other.Certificate}, Options{Purpose: x509.ExtKeyUsageAny})
+
+			Convey("it explains the issuer is wrong", func() {
+				So(err.Error(), ShouldContainSubstring, "wrong issuer")
+			})
+		})
+
+		Convey("when the intermediate has expired", func() {
+
+			expired := tests.MustGenerateIntermediate(t, root, keys.ECDSA, "P256", time.Now().Add(-2*time.Hour))
+			orphan := tests.MustGenerateLeaf(t, expired, keys.ECDSA, "P256")
+
+			_, err := Verify(orphan.Certificate, []*x509.Certificate{expired.Certificate}, roots, Options{Purpose: x509.ExtKeyUsageAny})
+
+			Convey("it explains the intermediate has expired", func() {
+				So(err.Error(), ShouldContainSubstring, "expired authority")
+			})
+		})
+	})
+}